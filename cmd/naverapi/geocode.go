@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/connectfit-team/naverapi/geocode"
+)
+
+// The Maps API is authenticated with the client ID and secret of an
+// application rather than with the account access keys.
+const (
+	envMapsClientID     = "NAVER_MAPS_CLIENT_ID"
+	envMapsClientSecret = "NAVER_MAPS_CLIENT_SECRET"
+)
+
+func runGeocode(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		opts         commonOptions
+		clientID     string
+		clientSecret string
+		lang         string
+		page         int
+		count        int
+	)
+	fs := newFlagSet("naverapi geocode", stderr)
+	opts.register(fs)
+	fs.StringVar(&clientID, "maps-client-id", os.Getenv(envMapsClientID), "client ID of the Maps application (default: $"+envMapsClientID+")")
+	fs.StringVar(&clientSecret, "maps-client-secret", os.Getenv(envMapsClientSecret), "client secret of the Maps application (default: $"+envMapsClientSecret+")")
+	fs.StringVar(&lang, "lang", string(geocode.LanguageKor), "language of the results: kor or eng")
+	fs.IntVar(&page, "page", 1, "page of the results")
+	fs.IntVar(&count, "count", 10, "number of results per page (1~100)")
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	err = opts.validate()
+	if err != nil {
+		return err
+	}
+	address := strings.Join(fs.Args(), " ")
+	if address == "" {
+		return errors.New("the address to look up is required")
+	}
+	if clientID == "" || clientSecret == "" {
+		return fmt.Errorf("the Maps client ID and secret are required: use --maps-client-id and --maps-client-secret or set %s and %s", envMapsClientID, envMapsClientSecret)
+	}
+
+	baseURL, err := opts.endpoint(geocode.OpenAPIGatewayHost)
+	if err != nil {
+		return err
+	}
+	client, err := geocode.NewClient(clientID, clientSecret, opts.httpClient(stdout))
+	if err != nil {
+		return err
+	}
+	client.BaseURL = baseURL
+	client.RetryPolicy = opts.retryPolicy()
+
+	resp, err := client.Query(ctx, address,
+		geocode.WithLanguage(geocode.Lang(lang)),
+		geocode.WithPage(page),
+		geocode.WithCount(count),
+	)
+	if opts.dryRun && errors.Is(err, errDryRun) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not look up the address: %w", err)
+	}
+
+	rows := make([]row, 0, len(resp.Addresses))
+	for _, a := range resp.Addresses {
+		rows = append(rows, row{a.RoadAddress, a.JibunAddress, a.X, a.Y})
+	}
+	return writeOutput(stdout, opts.output, resp,
+		row{"ROAD ADDRESS", "JIBUN ADDRESS", "X", "Y"},
+		rows,
+	)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/connectfit-team/naverapi/mailer"
+)
+
+func runMailSend(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		opts     commonOptions
+		from     string
+		fromName string
+		to       stringsFlag
+		cc       stringsFlag
+		bcc      stringsFlag
+		title    string
+		body     string
+		attach   stringsFlag
+	)
+	fs := newFlagSet("naverapi mail send", stderr)
+	opts.register(fs)
+	fs.StringVar(&from, "from", "", "sender address")
+	fs.StringVar(&fromName, "from-name", "", "sender name")
+	fs.Var(&to, "to", "recipient address, can be repeated")
+	fs.Var(&cc, "cc", "carbon copy recipient address, can be repeated")
+	fs.Var(&bcc, "bcc", "blind carbon copy recipient address, can be repeated")
+	fs.StringVar(&title, "title", "", "title of the mail")
+	fs.StringVar(&body, "body", "", "body of the mail")
+	fs.Var(&attach, "attach", "path of a file to attach, can be repeated")
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	err = opts.validate()
+	if err != nil {
+		return err
+	}
+	if from == "" || len(to)+len(cc)+len(bcc) == 0 || title == "" || body == "" {
+		return errors.New("--from, --to, --title and --body are required")
+	}
+
+	baseURL, err := opts.endpoint(mailer.CloudOutboundMailerGatewayHost)
+	if err != nil {
+		return err
+	}
+	client, err := mailer.NewCloudOutboundMailerClient("", "", opts.httpClient(stdout))
+	if err != nil {
+		return err
+	}
+	client.BaseURL = baseURL
+	client.Credentials = opts.credentials()
+	client.RetryPolicy = opts.retryPolicy()
+
+	req := mailer.CreateMailRequest{
+		SenderAddress: from,
+		SenderName:    fromName,
+		Title:         title,
+		Body:          body,
+	}
+	for _, recipients := range []struct {
+		addresses stringsFlag
+		typ       mailer.RecipientType
+	}{
+		{addresses: to, typ: mailer.RecipientTypeDefault},
+		{addresses: cc, typ: mailer.RecipientTypeCarbonCopy},
+		{addresses: bcc, typ: mailer.RecipientTypeBlindCarbonCopy},
+	} {
+		for _, address := range recipients.addresses {
+			req.Recipients = append(req.Recipients, &mailer.Recipient{Address: address, Type: recipients.typ})
+		}
+	}
+
+	if len(attach) > 0 {
+		req.AttachFileIDs, err = uploadAttachments(ctx, client, attach, opts.dryRun)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := client.CreateMail(ctx, req)
+	if opts.dryRun && errors.Is(err, errDryRun) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not send the mail: %w", err)
+	}
+
+	return writeOutput(stdout, opts.output, resp,
+		row{"REQUEST ID", "COUNT"},
+		[]row{{resp.RequestID, strconv.Itoa(resp.Count)}},
+	)
+}
+
+// uploadAttachments uploads the given files and returns their IDs.
+// In dry-run mode, placeholder IDs are returned once the upload request has
+// been printed.
+func uploadAttachments(ctx context.Context, client *mailer.CloudOutboundMailerClient, paths []string, dryRun bool) ([]string, error) {
+	files := make([]mailer.File, 0, len(paths))
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the attachment: %w", err)
+		}
+		files = append(files, mailer.File{Name: filepath.Base(path), Content: content})
+	}
+
+	resp, err := client.CreateFiles(ctx, files)
+	if dryRun && errors.Is(err, errDryRun) {
+		ids := make([]string, 0, len(files))
+		for i := range files {
+			ids = append(ids, fmt.Sprintf("dry-run-file-id-%d", i+1))
+		}
+		return ids, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not upload the attachments: %w", err)
+	}
+
+	ids := make([]string, 0, len(resp.Files))
+	for _, f := range resp.Files {
+		ids = append(ids, f.FileID)
+	}
+	return ids, nil
+}
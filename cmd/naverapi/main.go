@@ -0,0 +1,72 @@
+// Command naverapi is a thin command-line wrapper over the sens, mailer and
+// geocode clients, e.g. to send a test SMS or mail or to look up an address.
+//
+// Usage:
+//
+//	naverapi sens send --service-id <id> --from <number> --to <number> --content <text>
+//	naverapi mail send --from <address> --to <address> --title <title> --body <body> [--attach <file>]...
+//	naverapi geocode [--lang eng] "<address>"
+//
+// The credentials are read from the NCLOUD_ACCESS_KEY and NCLOUD_SECRET_KEY
+// environment variables, then from the ~/.ncloud/configure file. Use
+// --profile to read another profile of this file. The geocode command uses
+// the client ID and secret of a Maps application instead, read from the
+// NAVER_MAPS_CLIENT_ID and NAVER_MAPS_CLIENT_SECRET environment variables or
+// the --maps-client-id and --maps-client-secret flags.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+const usage = `naverapi is a command-line client for the Naver Cloud Platform APIs.
+
+Usage:
+  naverapi sens send [flags]     send a SMS
+  naverapi mail send [flags]     send a mail, optionally with attachments
+  naverapi geocode [flags] ADDR  look up an address
+
+Run "naverapi <command> -h" for the flags of a command.
+`
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	if err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "naverapi: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	switch {
+	case args[0] == "sens" && len(args) > 1 && args[1] == "send":
+		return runSENSSend(ctx, args[2:], stdout, stderr)
+	case args[0] == "mail" && len(args) > 1 && args[1] == "send":
+		return runMailSend(ctx, args[2:], stdout, stderr)
+	case args[0] == "geocode":
+		return runGeocode(ctx, args[1:], stdout, stderr)
+	case args[0] == "-h" || args[0] == "--help" || args[0] == "help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+}
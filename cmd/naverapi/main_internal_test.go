@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/connectfit-team/naverapi/naverapitest"
+	"github.com/connectfit-team/naverapi/sens"
+)
+
+func setTestCredentials(t *testing.T) {
+	t.Helper()
+
+	t.Setenv(naverapi.EnvAccessKey, "test-access-key")
+	t.Setenv(naverapi.EnvSecretKey, "test-secret-key")
+}
+
+func TestRun_SENSSend_DryRun(t *testing.T) {
+	setTestCredentials(t)
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{
+		"sens", "send", "--dry-run",
+		"--service-id", "ncp:sms:kr:123456789012:test_service",
+		"--from", "0212345678",
+		"--to", "01012345678",
+		"--content", "test-content",
+	}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("dry run shouldn't fail but got: %v (stderr: %s)", err, stderr.String())
+	}
+
+	got := stdout.String()
+	for _, want := range []string{
+		"POST /sms/v2/services/ncp:sms:kr:123456789012:test_service/messages HTTP/1.1",
+		"Host: sens.apigw.ntruss.com",
+		"X-Ncp-Apigw-Signature-V2: REDACTED",
+		"X-Ncp-Iam-Access-Key: REDACTED",
+		`"content":"test-content"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected the dry run output to contain %q but got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "test-access-key") {
+		t.Errorf("the dry run output shouldn't contain the access key but got:\n%s", got)
+	}
+}
+
+func TestRun_Geocode_DryRun(t *testing.T) {
+	setTestCredentials(t)
+	t.Setenv(envMapsClientID, "test-client-id")
+	t.Setenv(envMapsClientSecret, "test-client-secret")
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"geocode", "--dry-run", "불정로 6"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("dry run shouldn't fail but got: %v (stderr: %s)", err, stderr.String())
+	}
+
+	got := stdout.String()
+	for _, want := range []string{
+		"GET /map-geocode/v2/geocode?",
+		"Host: naveropenapi.apigw.ntruss.com",
+		"X-Ncp-Apigw-Api-Key-Id: test-client-id",
+		"X-Ncp-Apigw-Api-Key: REDACTED",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected the dry run output to contain %q but got:\n%s", want, got)
+		}
+	}
+	for _, secret := range []string{"test-client-secret", "test-access-key"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("the dry run output shouldn't contain %q but got:\n%s", secret, got)
+		}
+	}
+}
+
+func TestRun_Geocode_ShouldFailWithoutMapsCredentials(t *testing.T) {
+	setTestCredentials(t)
+	t.Setenv(envMapsClientID, "")
+	t.Setenv(envMapsClientSecret, "")
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"geocode", "--dry-run", "불정로 6"}, &stdout, &stderr)
+	if err == nil {
+		t.Errorf("looking up an address without the Maps credentials should fail")
+	}
+}
+
+func TestRun_MailSend_DryRunWithAttachment(t *testing.T) {
+	setTestCredentials(t)
+
+	attachment := filepath.Join(t.TempDir(), "report.txt")
+	err := os.WriteFile(attachment, []byte("test-content"), 0o600)
+	if err != nil {
+		t.Fatalf("could not write the attachment: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = run(context.Background(), []string{
+		"mail", "send", "--dry-run", "--site", "fin",
+		"--from", "no-reply@example.com",
+		"--to", "you@example.com",
+		"--title", "test-title",
+		"--body", "test-body",
+		"--attach", attachment,
+	}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("dry run shouldn't fail but got: %v (stderr: %s)", err, stderr.String())
+	}
+
+	got := stdout.String()
+	for _, want := range []string{
+		"POST /api/v1/files HTTP/1.1",
+		"POST /api/v1/mails HTTP/1.1",
+		"Host: mail.apigw.fin-ntruss.com",
+		`"attachFileIds":["dry-run-file-id-1"]`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected the dry run output to contain %q but got:\n%s", want, got)
+		}
+	}
+}
+
+func TestRun_SENSSend_JSONOutput(t *testing.T) {
+	setTestCredentials(t)
+
+	srv := naverapitest.NewServer("test-access-key", "test-secret-key")
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{
+		"sens", "send", "--output", "json", "--base-url", srv.URL,
+		"--service-id", "ncp:sms:kr:123456789012:test_service",
+		"--from", "0212345678",
+		"--to", "01012345678,01087654321",
+		"--content", "test-content",
+	}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("sending a SMS shouldn't fail but got: %v (stderr: %s)", err, stderr.String())
+	}
+
+	var resp sens.SendSMSResponse
+	err = json.Unmarshal(stdout.Bytes(), &resp)
+	if err != nil {
+		t.Fatalf("the output should be JSON but got: %v\n%s", err, stdout.String())
+	}
+	if resp.StatusName != "success" {
+		t.Errorf("expected status name %q but got %q", "success", resp.StatusName)
+	}
+
+	var req sens.SendSMSRequest
+	err = srv.RequestsTo(naverapitest.APISENSMessages)[0].DecodeJSON(&req)
+	if err != nil {
+		t.Fatalf("could not decode the recorded request: %v", err)
+	}
+	if len(req.Messages) != 2 {
+		t.Errorf("expected 2 messages but got %d", len(req.Messages))
+	}
+}
+
+func TestRun_ShouldFailIfUnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"fax"}, &stdout, &stderr)
+	if err == nil {
+		t.Errorf("running an unknown command should fail")
+	}
+}
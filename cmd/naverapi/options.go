@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	nethttputil "net/http/httputil"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/connectfit-team/naverapi/internal/httputil"
+)
+
+// errDryRun is returned by the dry-run transport instead of sending the
+// requests.
+var errDryRun = errors.New("dry run: the request has not been sent")
+
+const (
+	outputJSON  = "json"
+	outputTable = "table"
+)
+
+// commonOptions are the flags shared by every command.
+type commonOptions struct {
+	profile string
+	site    string
+	baseURL string
+	output  string
+	dryRun  bool
+}
+
+func (o *commonOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.profile, "profile", "", "profile of ~/.ncloud/configure to read the credentials from (default: environment, then DEFAULT profile)")
+	fs.StringVar(&o.site, "site", "public", "Naver Cloud Platform site: public, gov or fin")
+	fs.StringVar(&o.baseURL, "base-url", "", "base URL of the API, overriding the site one")
+	fs.StringVar(&o.output, "output", outputTable, "output format: json or table")
+	fs.BoolVar(&o.dryRun, "dry-run", false, "print the signed HTTP request instead of sending it")
+}
+
+func (o *commonOptions) validate() error {
+	if o.output != outputJSON && o.output != outputTable {
+		return fmt.Errorf("unknown output format %q", o.output)
+	}
+	_, err := naverapi.ParseSite(o.site)
+	return err
+}
+
+func (o *commonOptions) credentials() naverapi.CredentialsProvider {
+	if o.profile != "" {
+		return naverapi.NewProfileCredentials(o.profile)
+	}
+	return naverapi.DefaultCredentials()
+}
+
+// endpoint returns the base URL of the API whose gateway host prefix is given.
+func (o *commonOptions) endpoint(api string) (*url.URL, error) {
+	if o.baseURL != "" {
+		return url.Parse(o.baseURL)
+	}
+	site, err := naverapi.ParseSite(o.site)
+	if err != nil {
+		return nil, err
+	}
+	return site.BaseURL(api), nil
+}
+
+// httpClient returns the HTTP client used by the API clients. In dry-run mode,
+// its transport prints the requests to w instead of sending them.
+func (o *commonOptions) httpClient(w io.Writer) *http.Client {
+	if !o.dryRun {
+		return http.DefaultClient
+	}
+	return &http.Client{
+		Transport: &dryRunTransport{w: w},
+	}
+}
+
+// retryPolicy returns the retry policy of the API clients. The requests are
+// never retried in dry-run mode.
+func (o *commonOptions) retryPolicy() naverapi.RetryPolicy {
+	if o.dryRun {
+		return naverapi.NoRetry
+	}
+	return naverapi.DefaultRetryPolicy
+}
+
+// redactedHeaders are the headers carrying a secret, whose value is not
+// printed by the dry-run transport.
+var redactedHeaders = []string{
+	httputil.HeaderAccessKey,
+	httputil.HeaderSignatureV2,
+	"X-Ncp-Apigw-Api-Key",
+}
+
+type dryRunTransport struct {
+	w io.Writer
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for _, h := range redactedHeaders {
+		if req.Header.Get(h) != "" {
+			req.Header.Set(h, "REDACTED")
+		}
+	}
+	dump, err := nethttputil.DumpRequestOut(req, true)
+	if err != nil {
+		return nil, fmt.Errorf("could not dump the request: %w", err)
+	}
+	fmt.Fprintf(t.w, "%s\n\n", bytes.TrimRight(dump, "\r\n"))
+	return nil, errDryRun
+}
+
+// row is a line of the table output.
+type row []string
+
+func writeOutput(w io.Writer, format string, v any, header row, rows []row) error {
+	if format == outputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, r := range rows {
+		fmt.Fprintln(tw, strings.Join(r, "\t"))
+	}
+	return tw.Flush()
+}
+
+// stringsFlag is a flag which can be repeated or hold comma separated values.
+type stringsFlag []string
+
+func (sf *stringsFlag) String() string {
+	return strings.Join(*sf, ",")
+}
+
+func (sf *stringsFlag) Set(v string) error {
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*sf = append(*sf, s)
+		}
+	}
+	return nil
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// parseFlags parses the flags of a command, mapping the flag errors to
+// errUsage once they have been printed.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	return nil
+}
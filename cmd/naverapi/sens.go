@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/connectfit-team/naverapi/sens"
+)
+
+const envSENSServiceID = "NCLOUD_SENS_SERVICE_ID"
+
+func runSENSSend(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		opts      commonOptions
+		serviceID string
+		smsType   string
+		from      string
+		to        stringsFlag
+		subject   string
+		content   string
+		ad        bool
+	)
+	fs := newFlagSet("naverapi sens send", stderr)
+	opts.register(fs)
+	fs.StringVar(&serviceID, "service-id", os.Getenv(envSENSServiceID), "SENS SMS service ID (default: $"+envSENSServiceID+")")
+	fs.StringVar(&smsType, "type", string(sens.SMSTypeSMS), "message type: SMS, LMS or MMS")
+	fs.StringVar(&from, "from", "", "registered calling number")
+	fs.Var(&to, "to", "recipient number, can be repeated")
+	fs.StringVar(&subject, "subject", "", "subject of the message (LMS and MMS only)")
+	fs.StringVar(&content, "content", "", "content of the message")
+	fs.BoolVar(&ad, "ad", false, "send as an advertising message")
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	err = opts.validate()
+	if err != nil {
+		return err
+	}
+	if serviceID == "" || from == "" || len(to) == 0 || content == "" {
+		return errors.New("--service-id, --from, --to and --content are required")
+	}
+
+	baseURL, err := opts.endpoint(sens.SENSGatewayHost)
+	if err != nil {
+		return err
+	}
+	client, err := sens.NewClient("", "", serviceID, opts.httpClient(stdout))
+	if err != nil {
+		return err
+	}
+	client.BaseURL = baseURL
+	client.Credentials = opts.credentials()
+	client.RetryPolicy = opts.retryPolicy()
+
+	req := sens.SendSMSRequest{
+		Type:        sens.SMSType(smsType),
+		ContentType: sens.ContentTypeSMS,
+		CountryCode: sens.CountryCodeKorea,
+		From:        from,
+		Subject:     subject,
+		Content:     content,
+	}
+	if ad {
+		req.ContentType = sens.ContentTypeAD
+	}
+	for _, number := range to {
+		req.Messages = append(req.Messages, sens.Message{To: number})
+	}
+
+	resp, err := client.SendSMS(ctx, req)
+	if opts.dryRun && errors.Is(err, errDryRun) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not send the SMS: %w", err)
+	}
+
+	return writeOutput(stdout, opts.output, resp,
+		row{"REQUEST ID", "REQUEST TIME", "STATUS CODE", "STATUS NAME"},
+		[]row{{resp.RequestID, resp.RequestTime, resp.StatusCode, resp.StatusName}},
+	)
+}
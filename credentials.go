@@ -0,0 +1,260 @@
+package naverapi
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EnvAccessKey is the environment variable holding the access key.
+	EnvAccessKey = "NCLOUD_ACCESS_KEY"
+	// EnvSecretKey is the environment variable holding the secret key.
+	EnvSecretKey = "NCLOUD_SECRET_KEY"
+
+	// DefaultProfile is the profile read from the configuration file unless
+	// another one is given.
+	DefaultProfile = "DEFAULT"
+
+	profileAccessKeyKey = "ncloud_access_key_id"
+	profileSecretKeyKey = "ncloud_secret_access_key"
+)
+
+var (
+	// ErrNoCredentials is returned by a credentials provider which could not
+	// find any credentials.
+	ErrNoCredentials = errors.New("no credentials found")
+	// ErrProfileNotFound is returned when the requested profile does not
+	// exist in the configuration file.
+	ErrProfileNotFound = errors.New("profile not found")
+)
+
+// Credentials holds the keys used to authenticate to a Naver Cloud Platform
+// API.
+//
+// For the APIs signed with the API gateway signature (sens, mailer), they are
+// the access key and secret key from the portal or a sub account. For the
+// Maps APIs (geocode), they are the API key ID and API key of the
+// application.
+type Credentials struct {
+	AccessKey string
+	SecretKey string
+}
+
+// CredentialsProvider provides the credentials used to authenticate to the
+// APIs. The clients resolve them for each request, so that the keys can be
+// rotated without rebuilding the clients.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialsProviderFunc is an adapter to use an ordinary function as a
+// CredentialsProvider, e.g. to fetch the keys from a secret manager.
+type CredentialsProviderFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials implements the CredentialsProvider interface.
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentials provides always the same credentials.
+type StaticCredentials Credentials
+
+// NewStaticCredentials returns a provider always providing the given keys.
+func NewStaticCredentials(accessKey, secretKey string) StaticCredentials {
+	return StaticCredentials{
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+	}
+}
+
+// Credentials implements the CredentialsProvider interface.
+func (sc StaticCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	if sc.AccessKey == "" || sc.SecretKey == "" {
+		return Credentials{}, ErrNoCredentials
+	}
+	return Credentials(sc), nil
+}
+
+// EnvCredentials provides the credentials from the NCLOUD_ACCESS_KEY and
+// NCLOUD_SECRET_KEY environment variables.
+type EnvCredentials struct{}
+
+// Credentials implements the CredentialsProvider interface.
+func (EnvCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	creds := Credentials{
+		AccessKey: os.Getenv(EnvAccessKey),
+		SecretKey: os.Getenv(EnvSecretKey),
+	}
+	if creds.AccessKey == "" || creds.SecretKey == "" {
+		return Credentials{}, fmt.Errorf("%w in the %s and %s environment variables", ErrNoCredentials, EnvAccessKey, EnvSecretKey)
+	}
+	return creds, nil
+}
+
+// ProfileCredentials provides the credentials from a profile of the ncloud
+// CLI configuration file, which looks like:
+//
+//	[DEFAULT]
+//	ncloud_access_key_id = my-access-key
+//	ncloud_secret_access_key = my-secret-key
+//
+// The file is read again whenever it is modified.
+type ProfileCredentials struct {
+	// Path is the path of the configuration file.
+	// Defaults to ~/.ncloud/configure.
+	Path string
+	// Profile is the name of the profile to read.
+	// Defaults to DEFAULT.
+	Profile string
+
+	mu      sync.Mutex
+	modTime time.Time
+	cached  Credentials
+}
+
+// NewProfileCredentials returns a provider reading the given profile of the
+// default configuration file.
+func NewProfileCredentials(profile string) *ProfileCredentials {
+	return &ProfileCredentials{
+		Profile: profile,
+	}
+}
+
+// Credentials implements the CredentialsProvider interface.
+func (pc *ProfileCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	path, err := pc.path()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("could not stat the configuration file %q: %w", path, err)
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if !pc.modTime.IsZero() && info.ModTime().Equal(pc.modTime) {
+		return pc.cached, nil
+	}
+
+	creds, err := readProfile(path, pc.profile())
+	if err != nil {
+		return Credentials{}, err
+	}
+	pc.cached = creds
+	pc.modTime = info.ModTime()
+
+	return creds, nil
+}
+
+func (pc *ProfileCredentials) path() (string, error) {
+	if pc.Path != "" {
+		return pc.Path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not find the home directory: %w", err)
+	}
+	return filepath.Join(home, ".ncloud", "configure"), nil
+}
+
+func (pc *ProfileCredentials) profile() string {
+	if pc.Profile != "" {
+		return pc.Profile
+	}
+	return DefaultProfile
+}
+
+func readProfile(path, profile string) (Credentials, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("could not open the configuration file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	var (
+		creds   Credentials
+		found   bool
+		current string
+	)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			current = strings.TrimSpace(line[1 : len(line)-1])
+			found = found || current == profile
+			continue
+		case current != profile:
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case profileAccessKeyKey:
+			creds.AccessKey = strings.TrimSpace(value)
+		case profileSecretKeyKey:
+			creds.SecretKey = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Credentials{}, fmt.Errorf("could not read the configuration file %q: %w", path, err)
+	}
+
+	if !found {
+		return Credentials{}, fmt.Errorf("%w: %q in %q", ErrProfileNotFound, profile, path)
+	}
+	if creds.AccessKey == "" || creds.SecretKey == "" {
+		return Credentials{}, fmt.Errorf("%w in the profile %q of %q", ErrNoCredentials, profile, path)
+	}
+
+	return creds, nil
+}
+
+// ChainCredentials provides the credentials of the first of its providers
+// which succeeds.
+type ChainCredentials []CredentialsProvider
+
+// NewChainCredentials returns a provider trying each of the given providers
+// in turn.
+func NewChainCredentials(providers ...CredentialsProvider) ChainCredentials {
+	return ChainCredentials(providers)
+}
+
+// DefaultCredentials returns a provider looking for the credentials in the
+// environment variables first, then in the default profile of the ncloud CLI
+// configuration file.
+func DefaultCredentials() ChainCredentials {
+	return NewChainCredentials(EnvCredentials{}, NewProfileCredentials(DefaultProfile))
+}
+
+// Credentials implements the CredentialsProvider interface.
+func (cc ChainCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	errs := make([]string, 0, len(cc))
+	for _, p := range cc {
+		creds, err := p.Credentials(ctx)
+		if err == nil {
+			return creds, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	if len(errs) == 0 {
+		return Credentials{}, ErrNoCredentials
+	}
+	return Credentials{}, fmt.Errorf("%w in the chain: %s", ErrNoCredentials, strings.Join(errs, "; "))
+}
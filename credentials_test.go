@@ -0,0 +1,144 @@
+package naverapi_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/google/go-cmp/cmp"
+)
+
+const testConfigureFile = `
+[DEFAULT]
+ncloud_access_key_id = default-access-key
+ncloud_secret_access_key = default-secret-key
+ncloud_api_url = https://ncloud.apigw.ntruss.com
+
+[fin]
+ncloud_access_key_id = fin-access-key
+ncloud_secret_access_key = fin-secret-key
+`
+
+func writeTestConfigureFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "configure")
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatalf("could not write the configuration file: %v", err)
+	}
+	return path
+}
+
+func TestProfileCredentials_Credentials(t *testing.T) {
+	path := writeTestConfigureFile(t, testConfigureFile)
+
+	tests := []struct {
+		profile string
+		want    naverapi.Credentials
+	}{
+		{
+			profile: "",
+			want:    naverapi.Credentials{AccessKey: "default-access-key", SecretKey: "default-secret-key"},
+		},
+		{
+			profile: "fin",
+			want:    naverapi.Credentials{AccessKey: "fin-access-key", SecretKey: "fin-secret-key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			pc := &naverapi.ProfileCredentials{Path: path, Profile: tt.profile}
+			got, err := pc.Credentials(context.Background())
+			if err != nil {
+				t.Fatalf("reading an existing profile shouldn't fail but got: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("Credentials differ from the expected ones: %s", diff)
+			}
+		})
+	}
+}
+
+func TestProfileCredentials_Credentials_ShouldReloadModifiedFile(t *testing.T) {
+	path := writeTestConfigureFile(t, testConfigureFile)
+	pc := &naverapi.ProfileCredentials{Path: path}
+
+	_, err := pc.Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("reading an existing profile shouldn't fail but got: %v", err)
+	}
+
+	err = os.WriteFile(path, []byte("[DEFAULT]\nncloud_access_key_id = rotated-access-key\nncloud_secret_access_key = rotated-secret-key\n"), 0o600)
+	if err != nil {
+		t.Fatalf("could not write the configuration file: %v", err)
+	}
+	future := time.Now().Add(time.Hour)
+	err = os.Chtimes(path, future, future)
+	if err != nil {
+		t.Fatalf("could not change the configuration file times: %v", err)
+	}
+
+	got, err := pc.Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("reading an existing profile shouldn't fail but got: %v", err)
+	}
+	want := naverapi.Credentials{AccessKey: "rotated-access-key", SecretKey: "rotated-secret-key"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Credentials differ from the expected ones: %s", diff)
+	}
+}
+
+func TestProfileCredentials_Credentials_ShouldFailIfUnknownProfile(t *testing.T) {
+	path := writeTestConfigureFile(t, testConfigureFile)
+	pc := &naverapi.ProfileCredentials{Path: path, Profile: "gov"}
+
+	_, err := pc.Credentials(context.Background())
+	if !errors.Is(err, naverapi.ErrProfileNotFound) {
+		t.Errorf("expected error %v but got: %v", naverapi.ErrProfileNotFound, err)
+	}
+}
+
+func TestChainCredentials_Credentials(t *testing.T) {
+	t.Setenv(naverapi.EnvAccessKey, "")
+	t.Setenv(naverapi.EnvSecretKey, "")
+
+	chain := naverapi.NewChainCredentials(
+		naverapi.EnvCredentials{},
+		naverapi.NewStaticCredentials("static-access-key", "static-secret-key"),
+	)
+
+	got, err := chain.Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("the chain should fall back on the static credentials but got: %v", err)
+	}
+	want := naverapi.Credentials{AccessKey: "static-access-key", SecretKey: "static-secret-key"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Credentials differ from the expected ones: %s", diff)
+	}
+
+	t.Setenv(naverapi.EnvAccessKey, "env-access-key")
+	t.Setenv(naverapi.EnvSecretKey, "env-secret-key")
+
+	got, err = chain.Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("the chain should use the environment variables but got: %v", err)
+	}
+	want = naverapi.Credentials{AccessKey: "env-access-key", SecretKey: "env-secret-key"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Credentials differ from the expected ones: %s", diff)
+	}
+}
+
+func TestChainCredentials_Credentials_ShouldFailIfNoProviderSucceeds(t *testing.T) {
+	chain := naverapi.NewChainCredentials(naverapi.NewStaticCredentials("", ""))
+
+	_, err := chain.Credentials(context.Background())
+	if !errors.Is(err, naverapi.ErrNoCredentials) {
+		t.Errorf("expected error %v but got: %v", naverapi.ErrNoCredentials, err)
+	}
+}
@@ -0,0 +1,164 @@
+// Package naverapi gathers the building blocks shared by the Naver Cloud
+// Platform API clients of this module (sens, mailer and geocode).
+package naverapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxErrorBodySize is the maximum number of bytes of an error response body
+// kept in an APIError.
+const maxErrorBodySize = 64 << 10
+
+// APIError represents an error returned by a Naver Cloud Platform API.
+//
+// The clients of this module always return it as a *APIError, use errors.As
+// to retrieve it:
+//
+//	var apiErr *naverapi.APIError
+//	if errors.As(err, &apiErr) && apiErr.IsAuthFailure() {
+//		...
+//	}
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is the NCP error code (e.g. "200" for an API gateway
+	// authentication failure or "INVALID_REQUEST" for the geocode API).
+	Code string
+	// Message is the human readable error message sent by the API.
+	Message string
+	// RequestID is the ID of the request as returned by the API, if any.
+	RequestID string
+	// Body is the raw response body.
+	Body []byte
+	// Err is the underlying error, if any.
+	Err error
+}
+
+// errorBody gathers the different shapes of error bodies returned by the
+// API gateway and the APIs behind it.
+type errorBody struct {
+	Error *struct {
+		ErrorCode string `json:"errorCode"`
+		Message   string `json:"message"`
+		Details   string `json:"details"`
+	} `json:"error"`
+	ErrorCode    string `json:"errorCode"`
+	Message      string `json:"message"`
+	ErrorMessage string `json:"errorMessage"`
+	RequestID    string `json:"requestId"`
+}
+
+// NewAPIError builds an APIError from the given response, reading and
+// parsing its body. The response body is not closed.
+func NewAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		apiErr.Err = fmt.Errorf("could not read the response body: %w", err)
+	}
+	apiErr.Body = body
+
+	var eb errorBody
+	if len(bytes.TrimSpace(body)) == 0 || json.Unmarshal(body, &eb) != nil {
+		return apiErr
+	}
+
+	apiErr.RequestID = eb.RequestID
+	switch {
+	case eb.Error != nil:
+		apiErr.Code = eb.Error.ErrorCode
+		apiErr.Message = eb.Error.Message
+		if eb.Error.Details != "" {
+			apiErr.Message += ": " + eb.Error.Details
+		}
+	case eb.ErrorCode != "" || eb.Message != "":
+		apiErr.Code = eb.ErrorCode
+		apiErr.Message = eb.Message
+	default:
+		apiErr.Message = eb.ErrorMessage
+	}
+
+	return apiErr
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("request failed with status %d", e.StatusCode)
+	if e.Code != "" {
+		msg += fmt.Sprintf(" and error code %q", e.Code)
+	}
+	switch {
+	case e.Message != "":
+		msg += ": " + e.Message
+	case e.Err != nil:
+		msg += ": " + e.Err.Error()
+	case e.StatusCode != 0:
+		msg += ": " + http.StatusText(e.StatusCode)
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error, if any.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// IsRateLimited reports whether the request has been rejected because a
+// quota or a rate limit has been exceeded.
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsAuthFailure reports whether the request has been rejected because of
+// invalid credentials or missing permissions.
+func (e *APIError) IsAuthFailure() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// IsRetryable reports whether the failure is transient and the same request
+// may succeed if sent again later.
+func (e *APIError) IsRetryable() bool {
+	return isRetryableStatus(e.StatusCode)
+}
+
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRateLimited reports whether err is an APIError due to a rate limit.
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsRateLimited()
+}
+
+// IsAuthFailure reports whether err is an APIError due to invalid
+// credentials or missing permissions.
+func IsAuthFailure(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsAuthFailure()
+}
+
+// IsRetryable reports whether err is an APIError due to a transient failure.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsRetryable()
+}
@@ -0,0 +1,102 @@
+package naverapi_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *http.Response
+		want *naverapi.APIError
+	}{
+		{
+			name: "API gateway error body",
+			resp: newTestResponse(http.StatusUnauthorized, `{"error":{"errorCode":"200","message":"Authentication Failed","details":"Invalid authentication information."}}`),
+			want: &naverapi.APIError{
+				StatusCode: http.StatusUnauthorized,
+				Code:       "200",
+				Message:    "Authentication Failed: Invalid authentication information.",
+				Body:       []byte(`{"error":{"errorCode":"200","message":"Authentication Failed","details":"Invalid authentication information."}}`),
+			},
+		},
+		{
+			name: "Cloud Outbound Mailer error body",
+			resp: newTestResponse(http.StatusBadRequest, `{"requestId":"test-request-id","errorCode":"77101","message":"Invalid sender address"}`),
+			want: &naverapi.APIError{
+				StatusCode: http.StatusBadRequest,
+				Code:       "77101",
+				Message:    "Invalid sender address",
+				RequestID:  "test-request-id",
+				Body:       []byte(`{"requestId":"test-request-id","errorCode":"77101","message":"Invalid sender address"}`),
+			},
+		},
+		{
+			name: "malformed error body",
+			resp: newTestResponse(http.StatusInternalServerError, "malformed response body :)"),
+			want: &naverapi.APIError{
+				StatusCode: http.StatusInternalServerError,
+				Body:       []byte("malformed response body :)"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := naverapi.NewAPIError(tt.resp)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("APIError differ from the expected one: %s", diff)
+			}
+		})
+	}
+}
+
+func TestAPIError_Helpers(t *testing.T) {
+	tests := []struct {
+		statusCode      int
+		wantRateLimited bool
+		wantAuthFailure bool
+		wantRetryable   bool
+	}{
+		{statusCode: http.StatusBadRequest},
+		{statusCode: http.StatusUnauthorized, wantAuthFailure: true},
+		{statusCode: http.StatusForbidden, wantAuthFailure: true},
+		{statusCode: http.StatusTooManyRequests, wantRateLimited: true, wantRetryable: true},
+		{statusCode: http.StatusInternalServerError, wantRetryable: true},
+		{statusCode: http.StatusServiceUnavailable, wantRetryable: true},
+		{statusCode: http.StatusNotImplemented},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &naverapi.APIError{StatusCode: tt.statusCode})
+
+			if got := naverapi.IsRateLimited(err); got != tt.wantRateLimited {
+				t.Errorf("IsRateLimited() = %v, want %v", got, tt.wantRateLimited)
+			}
+			if got := naverapi.IsAuthFailure(err); got != tt.wantAuthFailure {
+				t.Errorf("IsAuthFailure() = %v, want %v", got, tt.wantAuthFailure)
+			}
+			if got := naverapi.IsRetryable(err); got != tt.wantRetryable {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.wantRetryable)
+			}
+		})
+	}
+
+	if naverapi.IsRetryable(errors.New("not an API error")) {
+		t.Errorf("IsRetryable() should be false for an error which is not an APIError")
+	}
+}
@@ -4,17 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+
+	"github.com/connectfit-team/naverapi"
 )
 
 const (
 	clientIDHeaderKey     = "X-NCP-APIGW-API-KEY-ID"
 	clientSecretHeaderKey = "X-NCP-APIGW-API-KEY"
 
-	OpenAPIBaseURL = "https://naveropenapi.apigw.ntruss.com"
-	Endpoint       = "/map-geocode/v2/geocode"
+	OpenAPIBaseURL     = "https://naveropenapi.apigw.ntruss.com"
+	OpenAPIGatewayHost = "naveropenapi"
+	Endpoint           = "/map-geocode/v2/geocode"
 )
 
 // param key
@@ -49,10 +53,13 @@ const (
 var ErrInvalidQuery = errors.New("invalid query parameter")
 
 type Client struct {
-	HTTPClient   *http.Client
-	BaseURL      *url.URL
-	clientID     string
-	clientSecret string
+	HTTPClient  *http.Client
+	BaseURL     *url.URL
+	RetryPolicy naverapi.RetryPolicy
+	Limiter     naverapi.Limiter
+	// Credentials provides the API key ID (as AccessKey) and the API key (as
+	// SecretKey) of the application, resolved for each request.
+	Credentials naverapi.CredentialsProvider
 }
 
 func NewClient(
@@ -65,10 +72,10 @@ func NewClient(
 		return nil, err
 	}
 	srv := &Client{
-		HTTPClient:   http.DefaultClient,
-		clientID:     clientID,
-		clientSecret: clientSecret,
-		BaseURL:      baseURL,
+		HTTPClient:  http.DefaultClient,
+		Credentials: naverapi.NewStaticCredentials(clientID, clientSecret),
+		BaseURL:     baseURL,
+		RetryPolicy: naverapi.DefaultRetryPolicy,
 	}
 
 	if httpClient != nil {
@@ -78,6 +85,12 @@ func NewClient(
 	return srv, nil
 }
 
+// UseSite points the client to the Maps API gateway of the given Naver Cloud
+// Platform site. BaseURL can still be set afterwards to use another host.
+func (c *Client) UseSite(site naverapi.Site) {
+	c.BaseURL = site.BaseURL(OpenAPIGatewayHost)
+}
+
 // Query address is required value.
 // Should always be called last.
 func (c *Client) Query(ctx context.Context, v string, opts ...QueryOption) (*Response, error) {
@@ -100,15 +113,42 @@ func (c *Client) request(ctx context.Context, data url.Values) (*Response, error
 	}
 	req.URL.RawQuery = data.Encode()
 
-	req.Header.Add(clientIDHeaderKey, c.clientID)
-	req.Header.Add(clientSecretHeaderKey, c.clientSecret)
+	if c.Credentials == nil {
+		return nil, naverapi.ErrNoCredentials
+	}
+	creds, err := c.Credentials.Credentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve the credentials: %w", err)
+	}
+	req.Header.Add(clientIDHeaderKey, creds.AccessKey)
+	req.Header.Add(clientSecretHeaderKey, creds.SecretKey)
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	client := *httpClient
+	client.Transport = naverapi.LimitTransport(c.Limiter, client.Transport)
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.RetryPolicy.Client(&client).Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		apiErr := naverapi.NewAPIError(resp)
+		// The geocode API reports its own status in the body of some errors.
+		var res Response
+		if apiErr.Code == "" && json.Unmarshal(apiErr.Body, &res) == nil && res.Status != "" {
+			apiErr.Code = res.Status
+			if apiErr.Message == "" {
+				apiErr.Message = res.ErrorMessage
+			}
+		}
+		return nil, apiErr
+	}
+
 	bytes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
@@ -118,7 +158,12 @@ func (c *Client) request(ctx context.Context, data url.Values) (*Response, error
 		return nil, err
 	}
 	if res.Status != OK {
-		return nil, errors.New(res.ErrorMessage)
+		return nil, &naverapi.APIError{
+			StatusCode: resp.StatusCode,
+			Code:       res.Status,
+			Message:    res.ErrorMessage,
+			Body:       bytes,
+		}
 	}
 	return res, nil
 }
@@ -3,11 +3,15 @@ package geocode_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 
+	"github.com/connectfit-team/naverapi"
 	"github.com/connectfit-team/naverapi/geocode"
 	"github.com/connectfit-team/naverapi/internal/testhelper"
 	"github.com/google/go-cmp/cmp"
@@ -279,3 +283,78 @@ func TestClient_Count(t *testing.T) {
 		t.Errorf("Not expected response: %s", diff)
 	}
 }
+
+func TestClient_Query_ShouldReturnAPIError(t *testing.T) {
+	client, mux, tearDown := setupTestClient()
+	defer tearDown()
+
+	mux.HandleFunc(geocode.Endpoint, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":{"errorCode":"200","message":"Authentication Failed"}}`)
+	})
+	_, err := client.Query(context.Background(), validAddr)
+	if !naverapi.IsAuthFailure(err) {
+		t.Fatalf("Expected an authentication failure but got : %v", err)
+	}
+
+	var apiErr *naverapi.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "200" {
+		t.Errorf("Expected API error code 200 but got : %v", err)
+	}
+}
+
+func TestClient_Query_ShouldReturnTheGeocodeStatus(t *testing.T) {
+	client, mux, tearDown := setupTestClient()
+	defer tearDown()
+
+	mux.HandleFunc(geocode.Endpoint, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"status":"INVALID_REQUEST","errorMessage":"query is INVALID"}`)
+	})
+	_, err := client.Query(context.Background(), validAddr)
+
+	var apiErr *naverapi.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an API error but got : %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != "INVALID_REQUEST" || apiErr.Message != "query is INVALID" {
+		t.Errorf("Expected the geocode status and message but got : %v", err)
+	}
+}
+
+type failingCredentials struct{ err error }
+
+func (fc failingCredentials) Credentials(ctx context.Context) (naverapi.Credentials, error) {
+	return naverapi.Credentials{}, fc.err
+}
+
+func TestClient_Query_ShouldUseTheDefaultHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"OK","addresses":[]}`)
+	}))
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	client := &geocode.Client{
+		BaseURL:     baseURL,
+		Credentials: naverapi.NewStaticCredentials("test-client-id", "test-client-secret"),
+	}
+
+	_, err := client.Query(context.Background(), validAddr)
+	if err != nil {
+		t.Fatalf("Query was given a client without HTTP client but failed: %v", err)
+	}
+}
+
+func TestClient_Query_ShouldWrapTheCredentialsErrors(t *testing.T) {
+	client, _, tearDown := setupTestClient()
+	defer tearDown()
+
+	credsErr := errors.New("test-error")
+	client.Credentials = failingCredentials{err: credsErr}
+
+	_, err := client.Query(context.Background(), validAddr)
+	if !errors.Is(err, credsErr) || !strings.Contains(err.Error(), "credentials") {
+		t.Errorf("Expected the credentials error to be wrapped but got: %v", err)
+	}
+}
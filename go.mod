@@ -2,4 +2,7 @@ module github.com/connectfit-team/naverapi
 
 go 1.19
 
-require github.com/google/go-cmp v0.5.9
+require (
+	github.com/google/go-cmp v0.5.9
+	golang.org/x/text v0.14.0
+)
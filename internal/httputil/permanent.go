@@ -0,0 +1,29 @@
+package httputil
+
+import "errors"
+
+// permanentError marks an error returned by a round tripper which can't be
+// fixed by sending the request again.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks the given error as not retryable, see IsPermanent. It
+// returns nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether the given error, or one it wraps, has been
+// marked with Permanent.
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
@@ -29,21 +29,11 @@ func NewJSONBodyRequest(ctx context.Context, method, endpoint string, body any)
 	return req, nil
 }
 
-func SetNCloudRequestHeaders(req *http.Request, url, timestamp, accessKey, secretKey string) error {
-	apigwSignature, err := formatAPIGatewaySignature(http.MethodPost, url, timestamp, accessKey, secretKey)
-	if err != nil {
-		return fmt.Errorf("could not format the API gateway signature: %w", err)
-	}
-
-	req.Header.Add("x-ncp-apigw-timestamp", timestamp)
-	req.Header.Add("x-ncp-iam-access-key", accessKey)
-	req.Header.Add("x-ncp-apigw-signature-v2", apigwSignature)
-
-	return nil
-}
-
+// FormatAPIGatewaySignature computes the `x-ncp-apigw-signature-v2` signature
+// of a request given its method, URI, timestamp and the keys.
+//
 // See https://api.ncloud-docs.com/docs/ai-application-service-cloudoutboundmailer
-func formatAPIGatewaySignature(method, url, timestamp, accessKey, secretKey string) (string, error) {
+func FormatAPIGatewaySignature(method, url, timestamp, accessKey, secretKey string) (string, error) {
 	var buf bytes.Buffer
 	buf.WriteString(method)
 	buf.WriteString(" ")
@@ -0,0 +1,111 @@
+package httputil_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi/internal/httputil"
+	"github.com/connectfit-team/naverapi/internal/testhelper"
+)
+
+type fixedTimeClock struct {
+	fixedTime time.Time
+}
+
+func (ftc fixedTimeClock) Now() time.Time { return ftc.fixedTime }
+
+var testSigner = httputil.Signer{
+	Credentials: httputil.StaticCredentials("test-access-key", "test-secret-key"),
+	Clock: fixedTimeClock{
+		fixedTime: time.Date(1997, 02, 26, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestSigner_Sign(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		url           string
+		wantSignature string
+	}{
+		{
+			name:          "GET request with a query string",
+			method:        http.MethodGet,
+			url:           "https://mail.apigw.ntruss.com/api/v1/mails?pageSize=10&requestId=abc",
+			wantSignature: "rMUPq8he0KBoeoyUTPRODU67zF4SqickSOuikELWp7I=",
+		},
+		{
+			name:          "DELETE request without query string",
+			method:        http.MethodDelete,
+			url:           "https://sens.apigw.ntruss.com/sms/v2/services/svc/reservations/r-1",
+			wantSignature: "zDocYk48trNNM45LgBXsbkEw/rDb7sCVHlvxK1khTpk=",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatalf("could not build the request: %v", err)
+			}
+
+			err = testSigner.Sign(req)
+			if err != nil {
+				t.Fatalf("signing a valid request shouldn't fail but got: %v", err)
+			}
+
+			testhelper.TestRequestHeader(t, req, "X-Ncp-Apigw-Timestamp", "856915200000")
+			testhelper.TestRequestHeader(t, req, "X-Ncp-Iam-Access-Key", "test-access-key")
+			testhelper.TestRequestHeader(t, req, "X-Ncp-Apigw-Signature-V2", tt.wantSignature)
+		})
+	}
+}
+
+func TestNewSignedClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Timestamp", "856915200000")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Iam-Access-Key", "test-access-key")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "rMUPq8he0KBoeoyUTPRODU67zF4SqickSOuikELWp7I=")
+	}))
+	defer srv.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/api/v1/mails?pageSize=10&requestId=abc", nil)
+	if err != nil {
+		t.Fatalf("could not build the request: %v", err)
+	}
+
+	resp, err := httputil.NewSignedClient(srv.Client(), testSigner).Do(req)
+	if err != nil {
+		t.Fatalf("performing the request shouldn't fail but got: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := req.Header.Get("X-Ncp-Apigw-Signature-V2"); got != "" {
+		t.Errorf("the original request shouldn't be modified but got signature %q", got)
+	}
+}
+
+func TestTransport_RoundTrip_ShouldMarkSigningErrorsAsPermanent(t *testing.T) {
+	errNoCredentials := errors.New("test-no-credentials")
+	transport := &httputil.Transport{
+		Signer: httputil.Signer{
+			Credentials: func(ctx context.Context) (string, string, error) {
+				return "", "", errNoCredentials
+			},
+		},
+	}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://localhost/", nil)
+	_, err := transport.RoundTrip(req)
+	if !errors.Is(err, errNoCredentials) {
+		t.Fatalf("expected error %v but got: %v", errNoCredentials, err)
+	}
+	if !httputil.IsPermanent(err) {
+		t.Errorf("expected the signing error to be permanent but got: %v", err)
+	}
+}
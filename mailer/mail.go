@@ -6,14 +6,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
 
+	"github.com/connectfit-team/naverapi"
 	"github.com/connectfit-team/naverapi/internal/httputil"
 )
 
 const (
 	CloudOutboundMailerDefaultBaseURL = "https://mail.apigw.ntruss.com" // [Base URL of the API]: https://api.ncloud-docs.com/docs/en/ai-application-service-cloudoutboundmailer
 
+	CloudOutboundMailerGatewayHost = "mail"
+
 	EndpointFiles = "/api/v1/files" // [Files endpoint]: https://api.ncloud-docs.com/docs/en/ai-application-service-cloudoutboundmailer-createfile
 	EndpointMails = "/api/v1/mails" // [Mails endpoint]: https://api.ncloud-docs.com/docs/en/ai-application-service-cloudoutboundmailer-createmailrequest
 )
@@ -26,10 +28,16 @@ type CloudOutboundMailerClient struct {
 	// BaseURL is the base URL which prefix every request's URL path.
 	// e.g. https://sens.apigw.ntruss.com
 	BaseURL *url.URL
-	// AccessKey is the access key (from portal or sub account)
-	AccessKey string
-	// SecretKey is the secret key (from portal or sub account)
-	SecretKey string
+	// Credentials provides the access key and secret key (from portal or sub
+	// account) used to sign each request.
+	// Replace it to use another provider, e.g. naverapi.DefaultCredentials().
+	Credentials naverapi.CredentialsProvider
+	// RetryPolicy describes how the failed requests are retried.
+	// Set it to naverapi.NoRetry to send each request only once.
+	RetryPolicy naverapi.RetryPolicy
+	// Limiter, if set, limits the rate at which the requests are sent.
+	// Share the same limiter between the clients using the same credentials.
+	Limiter naverapi.Limiter
 	// Clock provides the current time used to fill the `x-ncp-apigw-timestamp`
 	// header of each request to the API.
 	// It has been made public mainly for testing purpose to avoid polluting the
@@ -47,11 +55,11 @@ func NewCloudOutboundMailerClient(accessKey, secretKey string, httpClient *http.
 	}
 
 	svc := &CloudOutboundMailerClient{
-		HTTPClient: http.DefaultClient,
-		BaseURL:    url,
-		AccessKey:  accessKey,
-		SecretKey:  secretKey,
-		Clock:      &realClock{},
+		HTTPClient:  http.DefaultClient,
+		BaseURL:     url,
+		Credentials: naverapi.NewStaticCredentials(accessKey, secretKey),
+		RetryPolicy: naverapi.DefaultRetryPolicy,
+		Clock:       &realClock{},
 	}
 
 	if httpClient != nil {
@@ -61,7 +69,38 @@ func NewCloudOutboundMailerClient(accessKey, secretKey string, httpClient *http.
 	return svc, nil
 }
 
+// UseSite points the client to the Cloud Outbound Mailer API gateway of the
+// given Naver Cloud Platform site. BaseURL can still be set afterwards to use
+// another host.
+func (comc *CloudOutboundMailerClient) UseSite(site naverapi.Site) {
+	comc.BaseURL = site.BaseURL(CloudOutboundMailerGatewayHost)
+}
+
+// credentials resolves the keys used to sign the requests.
+func (comc *CloudOutboundMailerClient) credentials(ctx context.Context) (accessKey, secretKey string, err error) {
+	if comc.Credentials == nil {
+		return "", "", naverapi.ErrNoCredentials
+	}
+	creds, err := comc.Credentials.Credentials(ctx)
+	return creds.AccessKey, creds.SecretKey, err
+}
+
+// do performs the given request, signing it with the client credentials once
+// allowed by the client limiter and retrying it according to the client retry
+// policy.
+func (comc *CloudOutboundMailerClient) do(req *http.Request) (*http.Response, error) {
+	signer := httputil.Signer{
+		Credentials: comc.credentials,
+		Clock:       comc.Clock,
+	}
+	client := httputil.NewSignedClient(comc.HTTPClient, signer)
+	client.Transport = naverapi.LimitTransport(comc.Limiter, client.Transport)
+	return comc.RetryPolicy.Client(client).Do(req)
+}
+
 // Error represents an API request error.
+// The clients return failed requests as a *naverapi.APIError holding the
+// error code and message of this body.
 type Error struct {
 	ErrorCode string `json:"errorCode"`
 	Message   string `json:"message"`
@@ -107,20 +146,14 @@ func (comc *CloudOutboundMailerClient) CreateMail(ctx context.Context, req Creat
 		return CreateMailResponse{}, fmt.Errorf("could not build the createMail request: %w", err)
 	}
 
-	timestamp := strconv.FormatInt(comc.Clock.Now().UnixMilli(), 10)
-	err = httputil.SetNCloudRequestHeaders(httpReq, EndpointMails, timestamp, comc.AccessKey, comc.SecretKey)
-	if err != nil {
-		return CreateMailResponse{}, fmt.Errorf("failed to set the HTTP header of the request: %w", err)
-	}
-
-	resp, err := comc.HTTPClient.Do(httpReq)
+	resp, err := comc.do(httpReq)
 	if err != nil {
 		return CreateMailResponse{}, fmt.Errorf("could not perform the HTTP request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return CreateMailResponse{}, fmt.Errorf("request failed with code %d: %s", resp.StatusCode, resp.Status)
+		return CreateMailResponse{}, naverapi.NewAPIError(resp)
 	}
 
 	var responseBody CreateMailResponse
@@ -165,20 +198,14 @@ func (comc *CloudOutboundMailerClient) CreateFiles(ctx context.Context, files []
 		return CreateFileResponse{}, fmt.Errorf("could not build the createFile request: %w", err)
 	}
 
-	timestamp := strconv.FormatInt(comc.Clock.Now().UnixMilli(), 10)
-	err = httputil.SetNCloudRequestHeaders(req, EndpointFiles, timestamp, comc.AccessKey, comc.SecretKey)
-	if err != nil {
-		return CreateFileResponse{}, fmt.Errorf("failed to set the HTTP header of the request: %w", err)
-	}
-
-	resp, err := comc.HTTPClient.Do(req)
+	resp, err := comc.do(req)
 	if err != nil {
 		return CreateFileResponse{}, fmt.Errorf("could not perform the HTTP request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return CreateFileResponse{}, fmt.Errorf("request failed with code %d: %s", resp.StatusCode, resp.Status)
+		return CreateFileResponse{}, naverapi.NewAPIError(resp)
 	}
 
 	var responseBody CreateFileResponse
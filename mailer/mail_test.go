@@ -2,10 +2,12 @@ package mailer_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
 
+	"github.com/connectfit-team/naverapi"
 	"github.com/connectfit-team/naverapi/mailer"
 	"github.com/google/go-cmp/cmp"
 )
@@ -188,3 +190,37 @@ func TestCloudOutboundMailerClient_CreateFiles_ShouldFailIfMalformedResponseBody
 		t.Fatalf("createFile request should fail when the server send status code %d", http.StatusBadRequest)
 	}
 }
+
+func TestCloudOutboundMailerClient_CreateMail_ShouldReturnAPIError(t *testing.T) {
+	client, mux, teardown := setupTestCloudOutboundMailerClient()
+	defer teardown()
+
+	mux.HandleFunc(mailer.EndpointMails, func(w http.ResponseWriter, r *http.Request) {
+		checkCreateMailRequest(t, r)
+
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"requestId":"test-request-id","errorCode":"test-error-code","message":"test-message"}`)
+	})
+
+	_, err := client.CreateMail(context.Background(), testCreateMailRequest)
+
+	var apiErr *naverapi.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("createMail request should fail with a *naverapi.APIError but got: %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != "test-error-code" || apiErr.Message != "test-message" || apiErr.RequestID != "test-request-id" {
+		t.Errorf("unexpected API error: %+v", apiErr)
+	}
+}
+
+func TestCloudOutboundMailerClient_UseSite(t *testing.T) {
+	client, _, teardown := setupTestCloudOutboundMailerClient()
+	defer teardown()
+
+	client.UseSite(naverapi.SiteFinancial)
+
+	want := "https://mail.apigw.fin-ntruss.com"
+	if got := client.BaseURL.String(); got != want {
+		t.Errorf("expected base URL %q but got %q", want, got)
+	}
+}
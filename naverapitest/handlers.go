@@ -0,0 +1,107 @@
+package naverapitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/connectfit-team/naverapi/geocode"
+	"github.com/connectfit-team/naverapi/mailer"
+	"github.com/connectfit-team/naverapi/sens"
+)
+
+// AddAddresses registers the addresses returned by the geocode API for the
+// given query. Unknown queries return no address.
+func (s *Server) AddAddresses(query string, addresses ...geocode.Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addresses[query] = append(s.addresses[query], addresses...)
+}
+
+func (s *Server) handleSendSMS(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req sens.SendSMSRequest
+	err := json.Unmarshal(body, &req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "400", fmt.Sprintf("malformed request body: %v", err))
+		return
+	}
+	if len(req.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "400", "messages is required")
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, sens.SendSMSResponse{
+		RequestID:   s.newRequestID(),
+		RequestTime: time.Now().Format("2006-01-02T15:04:05.000"),
+		StatusCode:  "202",
+		StatusName:  "success",
+	})
+}
+
+func (s *Server) handleCreateMail(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req mailer.CreateMailRequest
+	err := json.Unmarshal(body, &req)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, mailer.Error{ErrorCode: "77101", Message: fmt.Sprintf("malformed request body: %v", err)})
+		return
+	}
+	if len(req.Recipients) == 0 {
+		writeJSON(w, http.StatusBadRequest, mailer.Error{ErrorCode: "77102", Message: "recipients is required"})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"requestId": s.newRequestID(),
+		"count":     len(req.Recipients),
+	})
+}
+
+func (s *Server) handleCreateFiles(w http.ResponseWriter, r *http.Request, body []byte) {
+	err := r.ParseMultipartForm(32 << 20)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, mailer.Error{ErrorCode: "77101", Message: fmt.Sprintf("malformed request body: %v", err)})
+		return
+	}
+
+	resp := map[string]any{
+		"tempRequestId": s.newRequestID(),
+	}
+	var files []mailer.ResponseFileInfo
+	for i, fh := range r.MultipartForm.File["fileList"] {
+		files = append(files, mailer.ResponseFileInfo{
+			FileName: fh.Filename,
+			FileSize: int(fh.Size),
+			FileID:   fmt.Sprintf("test-file-id-%d", i+1),
+		})
+	}
+	resp["files"] = files
+
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (s *Server) handleGeocode(w http.ResponseWriter, r *http.Request, body []byte) {
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		writeJSON(w, http.StatusOK, geocode.Response{
+			Status:       geocode.InvalidRequest,
+			ErrorMessage: "query is INVALID",
+		})
+		return
+	}
+
+	s.mu.Lock()
+	addresses := append([]geocode.Address(nil), s.addresses[query]...)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, geocode.Response{
+		Status: geocode.OK,
+		Meta: geocode.Meta{
+			TotalCount: int64(len(addresses)),
+			Page:       1,
+			Count:      int64(len(addresses)),
+		},
+		Addresses: addresses,
+	})
+}
@@ -0,0 +1,354 @@
+// Package naverapitest provides an in-process fake of the Naver Cloud Platform
+// APIs used by this module, for the tests of the code built on top of the
+// sens, mailer and geocode clients.
+//
+//	srv := naverapitest.NewServer("test-access-key", "test-secret-key")
+//	defer srv.Close()
+//
+//	client, _ := sens.NewClient("test-access-key", "test-secret-key", "test-service-id", srv.Client())
+//	client.BaseURL = srv.BaseURL()
+//
+// The server checks the authentication headers of every request, records the
+// requests it receives and can be scripted to fail.
+package naverapitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/connectfit-team/naverapi/geocode"
+	"github.com/connectfit-team/naverapi/internal/httputil"
+)
+
+// API identifies one of the faked APIs.
+type API string
+
+const (
+	APISENSMessages API = "sens/messages"
+	APIMails        API = "mailer/mails"
+	APIFiles        API = "mailer/files"
+	APIGeocode      API = "geocode"
+)
+
+const (
+	geocodeKeyIDHeader = "X-NCP-APIGW-API-KEY-ID"
+	geocodeKeyHeader   = "X-NCP-APIGW-API-KEY"
+)
+
+// Request is a request received by the server.
+type Request struct {
+	// API is the faked API the request was sent to, empty if unknown.
+	API API
+	// Method is the HTTP method of the request.
+	Method string
+	// URL is the URL of the request.
+	URL *url.URL
+	// Header holds the headers of the request.
+	Header http.Header
+	// Body is the raw body of the request.
+	Body []byte
+	// StatusCode is the status code the server answered with.
+	StatusCode int
+}
+
+// DecodeJSON decodes the JSON body of the request into v.
+func (r Request) DecodeJSON(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Failure is a scripted failure returned by the server instead of handling a
+// request.
+type Failure struct {
+	// API is the API whose next request fails. Any API if empty.
+	API API
+	// StatusCode is the status code of the response.
+	StatusCode int
+	// Header holds extra headers of the response, e.g. `Retry-After`.
+	Header http.Header
+	// Body is the body of the response.
+	Body string
+}
+
+// RateLimited returns a failure answering with the 429 status code and the
+// given `Retry-After` value in seconds.
+func RateLimited(api API, retryAfter int) Failure {
+	return Failure{
+		API:        api,
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{strconv.Itoa(retryAfter)}},
+		Body:       `{"error":{"errorCode":"400","message":"Quota Exceed"}}`,
+	}
+}
+
+// InternalError returns a failure answering with the 500 status code.
+func InternalError(api API) Failure {
+	return Failure{
+		API:        api,
+		StatusCode: http.StatusInternalServerError,
+		Body:       `{"error":{"errorCode":"500","message":"Internal Server Error"}}`,
+	}
+}
+
+// InvalidSignature returns a failure answering as the API gateway does when
+// the signature of a request is invalid.
+func InvalidSignature(api API) Failure {
+	return Failure{
+		API:        api,
+		StatusCode: http.StatusUnauthorized,
+		Body:       authenticationFailedBody,
+	}
+}
+
+const authenticationFailedBody = `{"error":{"errorCode":"200","message":"Authentication Failed","details":"Invalid authentication information."}}`
+
+// Server is a fake of the SENS SMS, Cloud Outbound Mailer and geocode APIs.
+type Server struct {
+	*httptest.Server
+
+	// AccessKey is the access key the signed requests must use.
+	AccessKey string
+	// SecretKey is the secret key the signed requests must be signed with.
+	SecretKey string
+	// GeocodeKeyID is the API key ID the geocode requests must use.
+	// Defaults to AccessKey.
+	GeocodeKeyID string
+	// GeocodeKey is the API key the geocode requests must use.
+	// Defaults to SecretKey.
+	GeocodeKey string
+
+	mu        sync.Mutex
+	requests  []Request
+	failures  []Failure
+	addresses map[string][]geocode.Address
+	nextID    int
+}
+
+// NewServer starts and returns a new fake server accepting the requests
+// authenticated with the given keys. The caller should call Close when done.
+func NewServer(accessKey, secretKey string) *Server {
+	s := &Server{
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		addresses: make(map[string][]geocode.Address),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL returns the URL to set as the BaseURL of the clients.
+func (s *Server) BaseURL() *url.URL {
+	u, _ := url.Parse(s.URL)
+	return u
+}
+
+// Requests returns the requests received by the server so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the requests received by the server so far for the given
+// API.
+func (s *Server) RequestsTo(api API) []Request {
+	var reqs []Request
+	for _, r := range s.Requests() {
+		if r.API == api {
+			reqs = append(reqs, r)
+		}
+	}
+	return reqs
+}
+
+// Reset forgets the received requests and the pending failures.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+	s.failures = nil
+}
+
+// FailNext makes the server answer the next requests matching the given
+// failures with them, in order.
+func (s *Server) FailNext(failures ...Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, failures...)
+}
+
+// route is the handler of a faked API.
+type route struct {
+	api     API
+	method  string
+	match   func(path string) bool
+	signed  bool
+	handler func(w http.ResponseWriter, r *http.Request, body []byte)
+}
+
+func (s *Server) routes() []route {
+	return []route{
+		{api: APISENSMessages, method: http.MethodPost, match: isSENSMessagesPath, signed: true, handler: s.handleSendSMS},
+		{api: APIMails, method: http.MethodPost, match: isPath("/api/v1/mails"), signed: true, handler: s.handleCreateMail},
+		{api: APIFiles, method: http.MethodPost, match: isPath("/api/v1/files"), signed: true, handler: s.handleCreateFiles},
+		{api: APIGeocode, method: http.MethodGet, match: isPath("/map-geocode/v2/geocode"), handler: s.handleGeocode},
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rec := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+	api := s.serve(rec, r, body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		API:        api,
+		Method:     r.Method,
+		URL:        r.URL,
+		Header:     r.Header.Clone(),
+		Body:       body,
+		StatusCode: rec.statusCode,
+	})
+	s.mu.Unlock()
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, body []byte) API {
+	for _, rt := range s.routes() {
+		if !rt.match(r.URL.Path) {
+			continue
+		}
+
+		if r.Method != rt.method {
+			writeError(w, http.StatusMethodNotAllowed, "300", "Not Found Exception")
+			return rt.api
+		}
+
+		if f, ok := s.popFailure(rt.api); ok {
+			writeFailure(w, f)
+			return rt.api
+		}
+
+		var authorized bool
+		if rt.signed {
+			authorized = s.checkSignature(r)
+		} else {
+			authorized = s.checkAPIKey(r)
+		}
+		if !authorized {
+			writeFailure(w, InvalidSignature(rt.api))
+			return rt.api
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		rt.handler(w, r, body)
+		return rt.api
+	}
+
+	writeError(w, http.StatusNotFound, "300", "Not Found Exception")
+	return ""
+}
+
+func (s *Server) popFailure(api API) (Failure, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.failures {
+		if f.API == "" || f.API == api {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			return f, true
+		}
+	}
+	return Failure{}, false
+}
+
+func (s *Server) checkSignature(r *http.Request) bool {
+	if r.Header.Get(httputil.HeaderAccessKey) != s.AccessKey {
+		return false
+	}
+
+	want, err := httputil.FormatAPIGatewaySignature(r.Method, httputil.SignatureURI(r), r.Header.Get(httputil.HeaderTimestamp), s.AccessKey, s.SecretKey)
+	if err != nil {
+		return false
+	}
+	return r.Header.Get(httputil.HeaderSignatureV2) == want
+}
+
+func (s *Server) checkAPIKey(r *http.Request) bool {
+	keyID, key := s.GeocodeKeyID, s.GeocodeKey
+	if keyID == "" {
+		keyID = s.AccessKey
+	}
+	if key == "" {
+		key = s.SecretKey
+	}
+	return r.Header.Get(geocodeKeyIDHeader) == keyID && r.Header.Get(geocodeKeyHeader) == key
+}
+
+func (s *Server) newRequestID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	return fmt.Sprintf("test-request-id-%d", s.nextID)
+}
+
+func isPath(path string) func(string) bool {
+	return func(p string) bool {
+		return p == path
+	}
+}
+
+func isSENSMessagesPath(p string) bool {
+	return strings.HasPrefix(p, "/sms/v2/services/") && strings.HasSuffix(p, "/messages")
+}
+
+func writeFailure(w http.ResponseWriter, f Failure) {
+	for k, values := range f.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(f.StatusCode)
+	io.WriteString(w, f.Body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, errorCode, message string) {
+	writeJSON(w, statusCode, map[string]any{
+		"error": map[string]string{
+			"errorCode": errorCode,
+			"message":   message,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (sr *statusRecorder) WriteHeader(statusCode int) {
+	sr.statusCode = statusCode
+	sr.ResponseWriter.WriteHeader(statusCode)
+}
@@ -0,0 +1,149 @@
+package naverapitest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/connectfit-team/naverapi/geocode"
+	"github.com/connectfit-team/naverapi/mailer"
+	"github.com/connectfit-team/naverapi/naverapitest"
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testAccessKey = "test-access-key"
+	testSecretKey = "test-secret-key"
+	testServiceID = "ncp:sms:kr:123456789012:test_service"
+)
+
+func setupTestSENSClient(t *testing.T, srv *naverapitest.Server, secretKey string) *sens.Client {
+	t.Helper()
+
+	client, err := sens.NewClient(testAccessKey, secretKey, testServiceID, srv.Client())
+	if err != nil {
+		t.Fatalf("could not create the SENS client: %v", err)
+	}
+	client.BaseURL = srv.BaseURL()
+	client.RetryPolicy = naverapi.NoRetry
+
+	return client
+}
+
+func TestServer_SENS(t *testing.T) {
+	srv := naverapitest.NewServer(testAccessKey, testSecretKey)
+	defer srv.Close()
+
+	client := setupTestSENSClient(t, srv, testSecretKey)
+
+	req := sens.SendSMSRequest{
+		Type:        sens.SMSTypeSMS,
+		ContentType: sens.ContentTypeSMS,
+		From:        "0212345678",
+		Content:     "test-content",
+		Messages:    []sens.Message{{To: "01012345678"}},
+	}
+	resp, err := client.SendSMS(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Send SMS request was given a valid request but failed: %v", err)
+	}
+	if resp.RequestID == "" {
+		t.Errorf("Expected the fake server to return a request ID")
+	}
+
+	reqs := srv.RequestsTo(naverapitest.APISENSMessages)
+	if len(reqs) != 1 {
+		t.Fatalf("Expected 1 recorded request but got %d", len(reqs))
+	}
+
+	var got sens.SendSMSRequest
+	err = reqs[0].DecodeJSON(&got)
+	if err != nil {
+		t.Fatalf("could not decode the recorded request: %v", err)
+	}
+	if diff := cmp.Diff(got, req); diff != "" {
+		t.Errorf("Recorded request differ from the sent one: %s", diff)
+	}
+}
+
+func TestServer_ShouldRejectInvalidSignature(t *testing.T) {
+	srv := naverapitest.NewServer(testAccessKey, testSecretKey)
+	defer srv.Close()
+
+	client := setupTestSENSClient(t, srv, "wrong-secret-key")
+
+	_, err := client.SendSMS(context.Background(), sens.SendSMSRequest{Messages: []sens.Message{{To: "01012345678"}}})
+	if !naverapi.IsAuthFailure(err) {
+		t.Errorf("Expected an authentication failure but got: %v", err)
+	}
+}
+
+func TestServer_FailNext(t *testing.T) {
+	srv := naverapitest.NewServer(testAccessKey, testSecretKey)
+	defer srv.Close()
+
+	client, err := mailer.NewCloudOutboundMailerClient(testAccessKey, testSecretKey, srv.Client())
+	if err != nil {
+		t.Fatalf("could not create the mailer client: %v", err)
+	}
+	client.BaseURL = srv.BaseURL()
+	client.RetryPolicy = naverapi.NoRetry
+
+	srv.FailNext(naverapitest.RateLimited(naverapitest.APIMails, 1), naverapitest.InternalError(naverapitest.APIMails))
+
+	req := mailer.CreateMailRequest{
+		SenderAddress: "no-reply@example.com",
+		Title:         "test-title",
+		Body:          "test-body",
+		Recipients:    []*mailer.Recipient{{Address: "you@example.com", Type: mailer.RecipientTypeDefault}},
+	}
+
+	_, err = client.CreateMail(context.Background(), req)
+	if !naverapi.IsRateLimited(err) {
+		t.Errorf("Expected the first request to be rate limited but got: %v", err)
+	}
+
+	_, err = client.CreateMail(context.Background(), req)
+	if !naverapi.IsRetryable(err) || naverapi.IsRateLimited(err) {
+		t.Errorf("Expected the second request to fail with an internal error but got: %v", err)
+	}
+
+	resp, err := client.CreateMail(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected the third request to succeed but got: %v", err)
+	}
+	if resp.Count != 1 {
+		t.Errorf("Expected a count of 1 but got %d", resp.Count)
+	}
+
+	files, err := client.CreateFiles(context.Background(), []mailer.File{{Name: "test.txt", Content: []byte("test-content")}})
+	if err != nil {
+		t.Fatalf("Expected the files to be created but got: %v", err)
+	}
+	if len(files.Files) != 1 || files.Files[0].FileName != "test.txt" {
+		t.Errorf("Unexpected created files: %+v", files.Files)
+	}
+}
+
+func TestServer_Geocode(t *testing.T) {
+	srv := naverapitest.NewServer(testAccessKey, testSecretKey)
+	defer srv.Close()
+
+	client, err := geocode.NewClient(testAccessKey, testSecretKey, srv.Client())
+	if err != nil {
+		t.Fatalf("could not create the geocode client: %v", err)
+	}
+	client.BaseURL = srv.BaseURL()
+
+	address := geocode.Address{RoadAddress: "경기도 성남시 분당구 불정로 6", X: "127.1", Y: "37.3"}
+	srv.AddAddresses("불정로 6", address)
+
+	resp, err := client.Query(context.Background(), "불정로 6")
+	if err != nil {
+		t.Fatalf("Expected the query to succeed but got: %v", err)
+	}
+	if diff := cmp.Diff(resp.Addresses, []geocode.Address{address}); diff != "" {
+		t.Errorf("Addresses differ from the expected ones: %s", diff)
+	}
+}
@@ -0,0 +1,443 @@
+// Package otp sends one-time verification codes by SMS with a SENS client and
+// verifies them, limiting the attempts and the sends per phone number.
+package otp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/connectfit-team/naverapi/sens"
+)
+
+const (
+	// DefaultTemplate is the default template of the messages holding the
+	// codes.
+	DefaultTemplate = "[인증번호] #{code}\n#{minutes}분 안에 입력해 주세요."
+	// DefaultCodeLength is the default number of digits of the codes.
+	DefaultCodeLength = 6
+	// DefaultTTL is the default time the codes can be verified for.
+	DefaultTTL = 3 * time.Minute
+	// DefaultMaxAttempts is the default number of failed verifications after
+	// which a code can't be verified anymore.
+	DefaultMaxAttempts = 5
+	// DefaultResendCooldown is the default minimum time between two codes
+	// sent to the same number.
+	DefaultResendCooldown = time.Minute
+	// DefaultMaxSends is the default maximum number of codes sent to the same
+	// number per throttling window.
+	DefaultMaxSends = 5
+	// DefaultThrottleWindow is the default duration of the throttling window.
+	DefaultThrottleWindow = time.Hour
+)
+
+var (
+	// ErrMissingSecret is returned when creating or using a Service without
+	// Secret.
+	ErrMissingSecret = errors.New("the secret the codes are hashed with is missing")
+	// ErrResendCooldown is returned when a code is sent to a number less than
+	// ResendCooldown after the previous one.
+	ErrResendCooldown = errors.New("a code has been sent to the number too recently")
+	// ErrThrottled is returned when MaxSends codes have already been sent to
+	// a number within the throttling window.
+	ErrThrottled = errors.New("too many codes have been sent to the number")
+	// ErrNoPendingCode is returned when verifying a code for a number which
+	// has no pending code.
+	ErrNoPendingCode = errors.New("the number has no pending code")
+	// ErrCodeExpired is returned when verifying an expired code.
+	ErrCodeExpired = errors.New("the code has expired")
+	// ErrCodeMismatch is returned when verifying a wrong code.
+	ErrCodeMismatch = errors.New("the code does not match")
+	// ErrTooManyAttempts is returned when verifying a code which failed
+	// MaxAttempts verifications already.
+	ErrTooManyAttempts = errors.New("too many failed attempts")
+)
+
+// RetryError is returned when a code can't be sent yet.
+type RetryError struct {
+	// Err is ErrResendCooldown or ErrThrottled.
+	Err error
+	// RetryAfter is the time after which a code can be sent again.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%v: retry after %v", e.Err, e.RetryAfter)
+}
+
+// Unwrap returns the underlying error.
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Sender sends the SMS holding the codes. It is implemented by *sens.Client.
+type Sender interface {
+	SendSMS(ctx context.Context, req sens.SendSMSRequest) (sens.SendSMSResponse, error)
+}
+
+// Service sends and verifies the codes.
+//
+// It serializes the operations on each number, so the attempt and send
+// limits hold for a single Service. Services sharing a Store across processes
+// may exceed them slightly under concurrent requests.
+//
+// Sender, Store and Secret are required, the other fields fall back to their
+// default when zero, so that a Service can be used without NewService.
+type Service struct {
+	// Sender sends the SMS holding the codes.
+	Sender Sender
+	// Store stores the hashed codes and the sends of each number.
+	Store Store
+	// From is the calling number the codes are sent from.
+	From string
+	// Secret is the key the codes are hashed with, which must be kept out of
+	// the Store so that the codes can't be guessed from it. Services sharing
+	// a Store must share the same Secret. It is required: Send and Verify
+	// fail with ErrMissingSecret if it is empty.
+	Secret []byte
+	// Template is the content of the messages, where #{code} is replaced by
+	// the code and #{minutes} by the TTL in minutes.
+	// Defaults to DefaultTemplate.
+	Template string
+	// CodeLength is the number of digits of the codes.
+	// Defaults to DefaultCodeLength.
+	CodeLength int
+	// TTL is the time the codes can be verified for.
+	// Defaults to DefaultTTL.
+	TTL time.Duration
+	// MaxAttempts is the number of failed verifications after which a code
+	// can't be verified anymore. Defaults to DefaultMaxAttempts, a negative
+	// value disables the limit.
+	MaxAttempts int
+	// ResendCooldown is the minimum time between two codes sent to the same
+	// number. Defaults to DefaultResendCooldown, a negative value disables
+	// the cooldown.
+	ResendCooldown time.Duration
+	// MaxSends is the maximum number of codes sent to the same number per
+	// ThrottleWindow. Defaults to DefaultMaxSends, a negative value disables
+	// the limit.
+	MaxSends int
+	// ThrottleWindow is the duration of the throttling window.
+	// Defaults to DefaultThrottleWindow.
+	ThrottleWindow time.Duration
+	// Clock provides the current time. The system time is used if nil.
+	Clock sens.Clock
+	// Rand is the source of the codes and salts. Defaults to crypto/rand.
+	Rand io.Reader
+
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+// keyLock serializes the operations on a number.
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewService returns a new Service sending the codes from the given calling
+// number with the given sender, storing them in the given store hashed with
+// the given secret and using the default settings.
+// It returns ErrMissingSecret if the secret is empty.
+func NewService(sender Sender, store Store, from string, secret []byte) (*Service, error) {
+	if len(secret) == 0 {
+		return nil, ErrMissingSecret
+	}
+
+	return &Service{
+		Sender:         sender,
+		Store:          store,
+		From:           from,
+		Secret:         secret,
+		Template:       DefaultTemplate,
+		CodeLength:     DefaultCodeLength,
+		TTL:            DefaultTTL,
+		MaxAttempts:    DefaultMaxAttempts,
+		ResendCooldown: DefaultResendCooldown,
+		MaxSends:       DefaultMaxSends,
+		ThrottleWindow: DefaultThrottleWindow,
+		Rand:           rand.Reader,
+	}, nil
+}
+
+// SendResult is the result of Send.
+type SendResult struct {
+	// RequestID is the ID of the SENS request which sent the code.
+	RequestID string
+	// ExpiresAt is the time after which the code can't be verified anymore.
+	ExpiresAt time.Time
+}
+
+// Send sends a new code to the given phone number, parsed with
+// sens.ParsePhoneNumber, replacing the pending one if any.
+// It returns a *RetryError if a code has been sent to the number less than
+// ResendCooldown ago or MaxSends codes have been sent to it within the
+// ThrottleWindow.
+func (s *Service) Send(ctx context.Context, phoneNumber string) (SendResult, error) {
+	if len(s.Secret) == 0 {
+		return SendResult{}, ErrMissingSecret
+	}
+	number, err := sens.ParsePhoneNumber(phoneNumber)
+	if err != nil {
+		return SendResult{}, err
+	}
+	key := number.E164()
+
+	unlock := s.lock(key)
+	defer unlock()
+
+	entry, err := s.entry(ctx, key)
+	if err != nil {
+		return SendResult{}, err
+	}
+
+	t := now(s.Clock)
+	window := s.throttleWindow()
+	var sends []time.Time
+	for _, sentAt := range entry.Sends {
+		if t.Before(sentAt.Add(window)) {
+			sends = append(sends, sentAt)
+		}
+	}
+	if cooldown := s.resendCooldown(); len(sends) > 0 && cooldown > 0 {
+		if retryAt := sends[len(sends)-1].Add(cooldown); t.Before(retryAt) {
+			return SendResult{}, &RetryError{Err: ErrResendCooldown, RetryAfter: retryAt.Sub(t)}
+		}
+	}
+	if maxSends := s.maxSends(); maxSends > 0 && len(sends) >= maxSends {
+		retryAt := sends[len(sends)-maxSends].Add(window)
+		return SendResult{}, &RetryError{Err: ErrThrottled, RetryAfter: retryAt.Sub(t)}
+	}
+
+	code, err := s.generateCode()
+	if err != nil {
+		return SendResult{}, fmt.Errorf("could not generate the code: %w", err)
+	}
+	salt := make([]byte, sha256.Size)
+	_, err = io.ReadFull(s.random(), salt)
+	if err != nil {
+		return SendResult{}, fmt.Errorf("could not generate the salt: %w", err)
+	}
+	hash := s.hashCode(salt, code)
+
+	resp, err := s.Sender.SendSMS(ctx, sens.SendSMSRequest{
+		Type:        sens.SMSTypeAuto,
+		ContentType: sens.ContentTypeSMS,
+		CountryCode: number.CountryCode,
+		From:        s.From,
+		Content:     s.render(code),
+		Messages:    []sens.Message{{To: number.Number}},
+	})
+	if err != nil {
+		return SendResult{}, fmt.Errorf("could not send the code: %w", err)
+	}
+
+	entry = Entry{
+		Salt:      salt,
+		Hash:      hash,
+		ExpiresAt: t.Add(s.ttl()),
+		Sends:     append(sends, t),
+	}
+	err = s.put(ctx, key, entry)
+	if err != nil {
+		return SendResult{}, err
+	}
+
+	return SendResult{RequestID: resp.RequestID, ExpiresAt: entry.ExpiresAt}, nil
+}
+
+// Verify verifies the given code sent to the given phone number. Once
+// verified, a code can't be verified again.
+// It returns an error wrapping ErrNoPendingCode, ErrCodeExpired,
+// ErrCodeMismatch or ErrTooManyAttempts if the code can't be verified.
+func (s *Service) Verify(ctx context.Context, phoneNumber, code string) error {
+	if len(s.Secret) == 0 {
+		return ErrMissingSecret
+	}
+	number, err := sens.ParsePhoneNumber(phoneNumber)
+	if err != nil {
+		return err
+	}
+	key := number.E164()
+
+	unlock := s.lock(key)
+	defer unlock()
+
+	entry, err := s.entry(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	maxAttempts := s.maxAttempts()
+	switch {
+	case entry.Hash == nil:
+		return ErrNoPendingCode
+	case !now(s.Clock).Before(entry.ExpiresAt):
+		return ErrCodeExpired
+	case maxAttempts > 0 && entry.Attempts >= maxAttempts:
+		return ErrTooManyAttempts
+	}
+
+	hash := s.hashCode(entry.Salt, code)
+	if subtle.ConstantTimeCompare(hash, entry.Hash) != 1 {
+		entry.Attempts++
+		err = s.put(ctx, key, entry)
+		if err != nil {
+			return err
+		}
+		if maxAttempts > 0 {
+			return fmt.Errorf("%w: %d attempts left", ErrCodeMismatch, maxAttempts-entry.Attempts)
+		}
+		return ErrCodeMismatch
+	}
+
+	entry.Salt, entry.Hash = nil, nil
+	return s.put(ctx, key, entry)
+}
+
+// entry returns the entry of the given key, the zero entry if there is none.
+func (s *Service) entry(ctx context.Context, key string) (Entry, error) {
+	entry, err := s.Store.Get(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		return Entry{}, nil
+	}
+	if err != nil {
+		return Entry{}, fmt.Errorf("could not get the entry of the number: %w", err)
+	}
+	return entry, nil
+}
+
+// put stores the given entry for as long as its code can be verified or its
+// sends are throttled.
+func (s *Service) put(ctx context.Context, key string, entry Entry) error {
+	expiresAt := entry.ExpiresAt
+	if n := len(entry.Sends); n > 0 {
+		if throttledUntil := entry.Sends[n-1].Add(s.throttleWindow()); throttledUntil.After(expiresAt) {
+			expiresAt = throttledUntil
+		}
+	}
+
+	err := s.Store.Put(ctx, key, entry, expiresAt)
+	if err != nil {
+		return fmt.Errorf("could not store the entry of the number: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) random() io.Reader {
+	if s.Rand == nil {
+		return rand.Reader
+	}
+	return s.Rand
+}
+
+// generateCode returns a random code of CodeLength digits.
+func (s *Service) generateCode() (string, error) {
+	length := s.CodeLength
+	if length <= 0 {
+		length = DefaultCodeLength
+	}
+
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
+	n, err := rand.Int(s.random(), limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", length, n), nil
+}
+
+// render returns the content of the message holding the given code.
+func (s *Service) render(code string) string {
+	template := s.Template
+	if template == "" {
+		template = DefaultTemplate
+	}
+
+	minutes := int((s.ttl() + time.Minute - 1) / time.Minute)
+	return strings.NewReplacer(
+		"#{code}", code,
+		"#{minutes}", strconv.Itoa(minutes),
+	).Replace(template)
+}
+
+// hashCode returns the HMAC of the given salted code keyed by the Secret.
+func (s *Service) hashCode(salt []byte, code string) []byte {
+	h := hmac.New(sha256.New, s.Secret)
+	h.Write(salt)
+	h.Write([]byte(code))
+	return h.Sum(nil)
+}
+
+// lock locks the operations on the given key and returns the function
+// unlocking them.
+func (s *Service) lock(key string) (unlock func()) {
+	s.mu.Lock()
+	if s.locks == nil {
+		s.locks = make(map[string]*keyLock)
+	}
+	l, ok := s.locks[key]
+	if !ok {
+		l = &keyLock{}
+		s.locks[key] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		s.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(s.locks, key)
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *Service) ttl() time.Duration {
+	if s.TTL <= 0 {
+		return DefaultTTL
+	}
+	return s.TTL
+}
+
+func (s *Service) maxAttempts() int {
+	if s.MaxAttempts == 0 {
+		return DefaultMaxAttempts
+	}
+	return s.MaxAttempts
+}
+
+func (s *Service) resendCooldown() time.Duration {
+	if s.ResendCooldown == 0 {
+		return DefaultResendCooldown
+	}
+	return s.ResendCooldown
+}
+
+func (s *Service) maxSends() int {
+	if s.MaxSends == 0 {
+		return DefaultMaxSends
+	}
+	return s.MaxSends
+}
+
+func (s *Service) throttleWindow() time.Duration {
+	if s.ThrottleWindow <= 0 {
+		return DefaultThrottleWindow
+	}
+	return s.ThrottleWindow
+}
@@ -0,0 +1,381 @@
+package otp_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi/otp"
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+var _ otp.Sender = (*sens.Client)(nil)
+
+type testClock struct {
+	now time.Time
+}
+
+func (tc *testClock) Now() time.Time { return tc.now }
+
+type testSender struct {
+	requests []sens.SendSMSRequest
+	err      error
+}
+
+func (ts *testSender) SendSMS(ctx context.Context, req sens.SendSMSRequest) (sens.SendSMSResponse, error) {
+	if ts.err != nil {
+		return sens.SendSMSResponse{}, ts.err
+	}
+	ts.requests = append(ts.requests, req)
+	return sens.SendSMSResponse{RequestID: "test-request-id", StatusName: "success"}, nil
+}
+
+var codePattern = regexp.MustCompile(`\d{4,}`)
+
+// lastCode returns the code of the last message sent by the given sender.
+func (ts *testSender) lastCode(t *testing.T) string {
+	t.Helper()
+
+	if len(ts.requests) == 0 {
+		t.Fatal("No code has been sent")
+	}
+	code := codePattern.FindString(ts.requests[len(ts.requests)-1].Content)
+	if code == "" {
+		t.Fatalf("No code in %q", ts.requests[len(ts.requests)-1].Content)
+	}
+	return code
+}
+
+func setupTestService() (*otp.Service, *testSender, *testClock) {
+	sender := &testSender{}
+	clock := &testClock{now: time.Date(1997, 2, 26, 0, 0, 0, 0, time.UTC)}
+
+	store := otp.NewMemoryStore()
+	store.Clock = clock
+
+	svc, _ := otp.NewService(sender, store, "0212345678", []byte("test-secret"))
+	svc.Clock = clock
+
+	return svc, sender, clock
+}
+
+func TestService_Send(t *testing.T) {
+	svc, sender, clock := setupTestService()
+
+	got, err := svc.Send(context.Background(), "010-1234-5678")
+	if err != nil {
+		t.Fatalf("Send was given a valid number but failed: %v", err)
+	}
+
+	want := otp.SendResult{RequestID: "test-request-id", ExpiresAt: clock.now.Add(otp.DefaultTTL)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected result (-want +got):\n%s", diff)
+	}
+
+	code := sender.lastCode(t)
+	if len(code) != otp.DefaultCodeLength {
+		t.Errorf("Expected a code of %d digits but got %q", otp.DefaultCodeLength, code)
+	}
+
+	wantReq := sens.SendSMSRequest{
+		Type:        sens.SMSTypeAuto,
+		ContentType: sens.ContentTypeSMS,
+		CountryCode: sens.CountryCodeKorea,
+		From:        "0212345678",
+		Content:     "[인증번호] " + code + "\n3분 안에 입력해 주세요.",
+		Messages:    []sens.Message{{To: "01012345678"}},
+	}
+	if diff := cmp.Diff(wantReq, sender.requests[0]); diff != "" {
+		t.Errorf("Unexpected request (-want +got):\n%s", diff)
+	}
+}
+
+func TestService_Send_ShouldUseTheTemplate(t *testing.T) {
+	svc, sender, _ := setupTestService()
+	svc.Template = "Your code is #{code}, valid #{minutes} minutes"
+	svc.CodeLength = 4
+	svc.TTL = 90 * time.Second
+
+	_, err := svc.Send(context.Background(), "+1 202-555-0123")
+	if err != nil {
+		t.Fatalf("Send was given a valid number but failed: %v", err)
+	}
+
+	req := sender.requests[0]
+	if want := "Your code is " + sender.lastCode(t) + ", valid 2 minutes"; req.Content != want {
+		t.Errorf("Expected content %q but got %q", want, req.Content)
+	}
+	if len(sender.lastCode(t)) != 4 {
+		t.Errorf("Expected a code of 4 digits but got %q", sender.lastCode(t))
+	}
+	if req.CountryCode != "1" || req.Messages[0].To != "2025550123" {
+		t.Errorf("Unexpected recipient %s %s", req.CountryCode, req.Messages[0].To)
+	}
+}
+
+func TestService_Send_ShouldEnforceTheCooldownAndThrottling(t *testing.T) {
+	svc, _, clock := setupTestService()
+	svc.MaxSends = 2
+
+	ctx := context.Background()
+	if _, err := svc.Send(ctx, "01012345678"); err != nil {
+		t.Fatalf("Send was given a valid number but failed: %v", err)
+	}
+
+	clock.now = clock.now.Add(30 * time.Second)
+	_, err := svc.Send(ctx, "01012345678")
+	var retryErr *otp.RetryError
+	if !errors.As(err, &retryErr) || !errors.Is(err, otp.ErrResendCooldown) {
+		t.Fatalf("Expected a *RetryError wrapping %v but got: %v", otp.ErrResendCooldown, err)
+	}
+	if retryErr.RetryAfter != 30*time.Second {
+		t.Errorf("Expected to retry after %v but got %v", 30*time.Second, retryErr.RetryAfter)
+	}
+
+	if _, err := svc.Send(ctx, "01087654321"); err != nil {
+		t.Fatalf("Expected the cooldown not to apply to other numbers but got: %v", err)
+	}
+
+	clock.now = clock.now.Add(30 * time.Second)
+	if _, err := svc.Send(ctx, "01012345678"); err != nil {
+		t.Fatalf("Send was called after the cooldown but failed: %v", err)
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	_, err = svc.Send(ctx, "01012345678")
+	if !errors.As(err, &retryErr) || !errors.Is(err, otp.ErrThrottled) {
+		t.Fatalf("Expected a *RetryError wrapping %v but got: %v", otp.ErrThrottled, err)
+	}
+	if retryErr.RetryAfter != 58*time.Minute {
+		t.Errorf("Expected to retry after %v but got %v", 58*time.Minute, retryErr.RetryAfter)
+	}
+
+	clock.now = clock.now.Add(58 * time.Minute)
+	if _, err := svc.Send(ctx, "01012345678"); err != nil {
+		t.Fatalf("Send was called after the throttling window but failed: %v", err)
+	}
+}
+
+func TestService_Send_ShouldNotCountFailedSends(t *testing.T) {
+	svc, sender, _ := setupTestService()
+
+	sender.err = errors.New("test-error")
+	_, err := svc.Send(context.Background(), "01012345678")
+	if !errors.Is(err, sender.err) {
+		t.Fatalf("Expected error %v but got: %v", sender.err, err)
+	}
+
+	sender.err = nil
+	if _, err := svc.Send(context.Background(), "01012345678"); err != nil {
+		t.Fatalf("Expected no cooldown after a failed send but got: %v", err)
+	}
+}
+
+func TestService_Verify(t *testing.T) {
+	svc, sender, _ := setupTestService()
+
+	ctx := context.Background()
+	if _, err := svc.Send(ctx, "01012345678"); err != nil {
+		t.Fatalf("Send was given a valid number but failed: %v", err)
+	}
+
+	err := svc.Verify(ctx, "+82 10-1234-5678", sender.lastCode(t))
+	if err != nil {
+		t.Fatalf("Verify was given the sent code but failed: %v", err)
+	}
+
+	err = svc.Verify(ctx, "01012345678", sender.lastCode(t))
+	if !errors.Is(err, otp.ErrNoPendingCode) {
+		t.Errorf("Expected error %v once verified but got: %v", otp.ErrNoPendingCode, err)
+	}
+}
+
+func TestService_Verify_ShouldLimitTheAttempts(t *testing.T) {
+	svc, sender, _ := setupTestService()
+	svc.MaxAttempts = 2
+
+	ctx := context.Background()
+	if _, err := svc.Send(ctx, "01012345678"); err != nil {
+		t.Fatalf("Send was given a valid number but failed: %v", err)
+	}
+	code := sender.lastCode(t)
+	wrong := "x" + code[1:]
+
+	for i := 0; i < 2; i++ {
+		err := svc.Verify(ctx, "01012345678", wrong)
+		if !errors.Is(err, otp.ErrCodeMismatch) {
+			t.Fatalf("Expected error %v but got: %v", otp.ErrCodeMismatch, err)
+		}
+	}
+
+	err := svc.Verify(ctx, "01012345678", code)
+	if !errors.Is(err, otp.ErrTooManyAttempts) {
+		t.Errorf("Expected error %v but got: %v", otp.ErrTooManyAttempts, err)
+	}
+}
+
+func TestService_Verify_ShouldRejectExpiredCodes(t *testing.T) {
+	svc, sender, clock := setupTestService()
+
+	ctx := context.Background()
+	if _, err := svc.Send(ctx, "01012345678"); err != nil {
+		t.Fatalf("Send was given a valid number but failed: %v", err)
+	}
+
+	clock.now = clock.now.Add(otp.DefaultTTL)
+	err := svc.Verify(ctx, "01012345678", sender.lastCode(t))
+	if !errors.Is(err, otp.ErrCodeExpired) {
+		t.Errorf("Expected error %v but got: %v", otp.ErrCodeExpired, err)
+	}
+
+	err = svc.Verify(ctx, "01087654321", "123456")
+	if !errors.Is(err, otp.ErrNoPendingCode) {
+		t.Errorf("Expected error %v but got: %v", otp.ErrNoPendingCode, err)
+	}
+}
+
+func TestService_Send_ShouldStoreHashedCodes(t *testing.T) {
+	svc, sender, _ := setupTestService()
+
+	ctx := context.Background()
+	if _, err := svc.Send(ctx, "01012345678"); err != nil {
+		t.Fatalf("Send was given a valid number but failed: %v", err)
+	}
+
+	entry, err := svc.Store.Get(ctx, "+821012345678")
+	if err != nil {
+		t.Fatalf("Expected the entry to be stored by E.164 number but got: %v", err)
+	}
+	if len(entry.Hash) == 0 || bytes.Contains(entry.Hash, []byte(sender.lastCode(t))) {
+		t.Errorf("Expected the code to be stored hashed but got %q", entry.Hash)
+	}
+}
+
+func TestService_ShouldApplyTheDefaultsToZeroFields(t *testing.T) {
+	sender := &testSender{}
+	clock := &testClock{now: time.Date(1997, 2, 26, 0, 0, 0, 0, time.UTC)}
+	store := otp.NewMemoryStore()
+	store.Clock = clock
+	svc := &otp.Service{Sender: sender, Store: store, From: "0212345678", Secret: []byte("test-secret"), Clock: clock}
+
+	ctx := context.Background()
+	got, err := svc.Send(ctx, "01012345678")
+	if err != nil {
+		t.Fatalf("Send was given a valid number but failed: %v", err)
+	}
+	if want := clock.now.Add(otp.DefaultTTL); !got.ExpiresAt.Equal(want) {
+		t.Errorf("Expected the code to expire at %v but got %v", want, got.ExpiresAt)
+	}
+	if want := "[인증번호] " + sender.lastCode(t) + "\n3분 안에 입력해 주세요."; sender.requests[0].Content != want {
+		t.Errorf("Expected content %q but got %q", want, sender.requests[0].Content)
+	}
+
+	_, err = svc.Send(ctx, "01012345678")
+	if !errors.Is(err, otp.ErrResendCooldown) {
+		t.Errorf("Expected error %v but got: %v", otp.ErrResendCooldown, err)
+	}
+
+	for i := 0; i < otp.DefaultMaxAttempts; i++ {
+		err := svc.Verify(ctx, "01012345678", "x")
+		if !errors.Is(err, otp.ErrCodeMismatch) {
+			t.Fatalf("Expected error %v but got: %v", otp.ErrCodeMismatch, err)
+		}
+	}
+	err = svc.Verify(ctx, "01012345678", sender.lastCode(t))
+	if !errors.Is(err, otp.ErrTooManyAttempts) {
+		t.Errorf("Expected error %v but got: %v", otp.ErrTooManyAttempts, err)
+	}
+}
+
+func TestService_Verify_ShouldUseTheSecret(t *testing.T) {
+	svc, sender, clock := setupTestService()
+
+	ctx := context.Background()
+	if _, err := svc.Send(ctx, "01012345678"); err != nil {
+		t.Fatalf("Send was given a valid number but failed: %v", err)
+	}
+
+	other, err := otp.NewService(sender, svc.Store, "0212345678", []byte("other-secret"))
+	if err != nil {
+		t.Fatalf("NewService was given a secret but failed: %v", err)
+	}
+	other.Clock = clock
+	err = other.Verify(ctx, "01012345678", sender.lastCode(t))
+	if !errors.Is(err, otp.ErrCodeMismatch) {
+		t.Fatalf("Expected error %v with another secret but got: %v", otp.ErrCodeMismatch, err)
+	}
+
+	other.Secret = []byte("test-secret")
+	err = other.Verify(ctx, "01012345678", sender.lastCode(t))
+	if err != nil {
+		t.Errorf("Verify was given the sent code and the same secret but failed: %v", err)
+	}
+}
+
+func TestService_ShouldRequireASecret(t *testing.T) {
+	sender := &testSender{}
+	_, err := otp.NewService(sender, otp.NewMemoryStore(), "0212345678", nil)
+	if !errors.Is(err, otp.ErrMissingSecret) {
+		t.Errorf("Expected error %v but got: %v", otp.ErrMissingSecret, err)
+	}
+
+	svc := &otp.Service{Sender: sender, Store: otp.NewMemoryStore(), From: "0212345678"}
+	_, err = svc.Send(context.Background(), "01012345678")
+	if !errors.Is(err, otp.ErrMissingSecret) {
+		t.Errorf("Expected error %v but got: %v", otp.ErrMissingSecret, err)
+	}
+	if len(sender.requests) != 0 {
+		t.Errorf("Expected no code to be sent without secret but got %d", len(sender.requests))
+	}
+	err = svc.Verify(context.Background(), "01012345678", "123456")
+	if !errors.Is(err, otp.ErrMissingSecret) {
+		t.Errorf("Expected error %v but got: %v", otp.ErrMissingSecret, err)
+	}
+}
+
+type blockingSender struct {
+	sending chan string
+	release chan struct{}
+}
+
+func (bs *blockingSender) SendSMS(ctx context.Context, req sens.SendSMSRequest) (sens.SendSMSResponse, error) {
+	bs.sending <- req.Messages[0].To
+	<-bs.release
+	return sens.SendSMSResponse{RequestID: "test-request-id"}, nil
+}
+
+func TestService_Send_ShouldNotBlockOtherNumbers(t *testing.T) {
+	sender := &blockingSender{sending: make(chan string), release: make(chan struct{})}
+	svc, _ := otp.NewService(sender, otp.NewMemoryStore(), "0212345678", []byte("test-secret"))
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := svc.Send(context.Background(), "01012345678")
+		done <- err
+	}()
+	<-sender.sending
+
+	go func() {
+		_, err := svc.Send(context.Background(), "01087654321")
+		done <- err
+	}()
+	select {
+	case to := <-sender.sending:
+		if to != "01087654321" {
+			t.Errorf("Expected a code to be sent to %q but got %q", "01087654321", to)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a code to be sent to another number while the first one is being sent")
+	}
+
+	close(sender.release)
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("Send was given a valid number but failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package otp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/connectfit-team/naverapi/sens"
+)
+
+// ErrNotFound should be returned by a Store which has no entry for the given
+// key.
+var ErrNotFound = errors.New("no entry for the phone number")
+
+// Entry is the state of the verification of a phone number.
+type Entry struct {
+	// Salt is the random salt the code is hashed with.
+	Salt []byte
+	// Hash is the HMAC of the salted pending code, keyed by the Service
+	// Secret, nil once it has been verified.
+	Hash []byte
+	// ExpiresAt is the time after which the pending code can't be verified
+	// anymore.
+	ExpiresAt time.Time
+	// Attempts is the number of failed verifications of the pending code.
+	Attempts int
+	// Sends are the times the codes have been sent at, within the throttling
+	// window, in order.
+	Sends []time.Time
+}
+
+// Store stores the verification entries of the phone numbers.
+type Store interface {
+	// Get returns the entry of the given key. It returns an error wrapping
+	// ErrNotFound if there is none or it has expired.
+	Get(ctx context.Context, key string) (Entry, error)
+	// Put stores the entry of the given key until the given time.
+	Put(ctx context.Context, key string, entry Entry, expiresAt time.Time) error
+}
+
+// MemoryStore is a Store keeping the entries in memory. It is safe for
+// concurrent use. The expired entries are evicted by Put, so that the store
+// doesn't grow with the numbers which are never verified.
+type MemoryStore struct {
+	// Clock provides the current time used to expire the entries.
+	// The system time is used if nil.
+	Clock sens.Clock
+
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	entry     Entry
+	expiresAt time.Time
+}
+
+// NewMemoryStore returns a new empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]memoryEntry)}
+}
+
+// Get implements the Store interface.
+func (ms *MemoryStore) Get(ctx context.Context, key string) (Entry, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	e, ok := ms.entries[key]
+	if !ok {
+		return Entry{}, ErrNotFound
+	}
+	if !now(ms.Clock).Before(e.expiresAt) {
+		delete(ms.entries, key)
+		return Entry{}, ErrNotFound
+	}
+	return e.entry, nil
+}
+
+// Put implements the Store interface.
+func (ms *MemoryStore) Put(ctx context.Context, key string, entry Entry, expiresAt time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if ms.entries == nil {
+		ms.entries = make(map[string]memoryEntry)
+	}
+	t := now(ms.Clock)
+	for k, e := range ms.entries {
+		if !t.Before(e.expiresAt) {
+			delete(ms.entries, k)
+		}
+	}
+	ms.entries[key] = memoryEntry{entry: entry, expiresAt: expiresAt}
+	return nil
+}
+
+// Len returns the number of entries in the store, including the expired ones
+// which have not been evicted yet.
+func (ms *MemoryStore) Len() int {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return len(ms.entries)
+}
+
+// now returns the current time according to the given clock, if any.
+func now(clock sens.Clock) time.Time {
+	if clock == nil {
+		return time.Now()
+	}
+	return clock.Now()
+}
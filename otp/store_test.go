@@ -0,0 +1,64 @@
+package otp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi/otp"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMemoryStore(t *testing.T) {
+	clock := &testClock{now: time.Date(1997, 2, 26, 0, 0, 0, 0, time.UTC)}
+	store := otp.NewMemoryStore()
+	store.Clock = clock
+
+	ctx := context.Background()
+	if _, err := store.Get(ctx, "+821012345678"); !errors.Is(err, otp.ErrNotFound) {
+		t.Fatalf("Expected error %v but got: %v", otp.ErrNotFound, err)
+	}
+
+	want := otp.Entry{Hash: []byte("test-hash"), Attempts: 1}
+	err := store.Put(ctx, "+821012345678", want, clock.now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	got, err := store.Get(ctx, "+821012345678")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected entry (-want +got):\n%s", diff)
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	if _, err := store.Get(ctx, "+821012345678"); !errors.Is(err, otp.ErrNotFound) {
+		t.Errorf("Expected error %v once expired but got: %v", otp.ErrNotFound, err)
+	}
+}
+
+func TestMemoryStore_Put_ShouldEvictTheExpiredEntries(t *testing.T) {
+	clock := &testClock{now: time.Date(1997, 2, 26, 0, 0, 0, 0, time.UTC)}
+	store := otp.NewMemoryStore()
+	store.Clock = clock
+
+	ctx := context.Background()
+	for _, key := range []string{"+821012345678", "+821087654321"} {
+		err := store.Put(ctx, key, otp.Entry{Hash: []byte("test-hash")}, clock.now.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	err := store.Put(ctx, "+821011112222", otp.Entry{Hash: []byte("test-hash")}, clock.now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if got := store.Len(); got != 1 {
+		t.Errorf("Expected the expired entries to be evicted but the store holds %d entries", got)
+	}
+}
@@ -0,0 +1,186 @@
+package naverapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/connectfit-team/naverapi/internal/httputil"
+)
+
+var (
+	// ErrRateLimitWaitExceedsDeadline is returned by a limiter when the
+	// context deadline would be exceeded before a request slot becomes
+	// available.
+	ErrRateLimitWaitExceedsDeadline = errors.New("waiting for the rate limiter would exceed the context deadline")
+	// ErrEmptyTokenBucket is returned by a token bucket which does not allow
+	// any request.
+	ErrEmptyTokenBucket = errors.New("the token bucket does not allow any request")
+)
+
+// Limiter limits the rate at which requests are sent.
+//
+// Wait blocks until a request can be sent or the context is done.
+// A *rate.Limiter from golang.org/x/time/rate satisfies this interface.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a Limiter allowing up to a given number of requests per
+// period. Its tokens are refilled continuously and up to Limit requests can
+// be sent at once when the bucket is full.
+//
+// A TokenBucket is safe for concurrent use and is meant to be shared by every
+// client using the same credentials, so that they share the same quota.
+type TokenBucket struct {
+	mu     sync.Mutex
+	limit  float64
+	rate   float64 // tokens per nanosecond
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a full token bucket allowing up to limit requests
+// per period, e.g. NewTokenBucket(10, time.Second).
+func NewTokenBucket(limit int, period time.Duration) *TokenBucket {
+	return &TokenBucket{
+		limit:  float64(limit),
+		rate:   float64(limit) / float64(period),
+		tokens: float64(limit),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or the context is done.
+// It fails immediately if the context deadline would be exceeded before a
+// token becomes available.
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	if tb.limit <= 0 {
+		return ErrEmptyTokenBucket
+	}
+
+	tb.mu.Lock()
+	now := time.Now()
+	tb.refill(now)
+
+	tb.tokens--
+	if tb.tokens >= 0 {
+		tb.mu.Unlock()
+		return nil
+	}
+
+	wait := time.Duration(-tb.tokens / tb.rate)
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(now) < wait {
+		tb.tokens++
+		tb.mu.Unlock()
+		return ErrRateLimitWaitExceedsDeadline
+	}
+	tb.mu.Unlock()
+
+	err := sleep(ctx, wait)
+	if err != nil {
+		tb.release()
+		return err
+	}
+
+	return nil
+}
+
+// release gives back a token taken by Wait.
+func (tb *TokenBucket) release() {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill(time.Now())
+	tb.tokens++
+	if tb.tokens > tb.limit {
+		tb.tokens = tb.limit
+	}
+}
+
+func (tb *TokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(tb.last)
+	if elapsed <= 0 {
+		return
+	}
+	tb.last = now
+
+	tb.tokens += float64(elapsed) * tb.rate
+	if tb.tokens > tb.limit {
+		tb.tokens = tb.limit
+	}
+}
+
+// NewQuotaLimiter returns a limiter enforcing both a per-second and a
+// per-day quota, as enforced by the Naver Cloud Platform APIs.
+// A quota lower or equal to 0 is not enforced.
+func NewQuotaLimiter(perSecond, perDay int) Limiter {
+	var limiters multiLimiter
+	if perSecond > 0 {
+		limiters = append(limiters, NewTokenBucket(perSecond, time.Second))
+	}
+	if perDay > 0 {
+		limiters = append(limiters, NewTokenBucket(perDay, 24*time.Hour))
+	}
+	return limiters
+}
+
+// MultiLimiter returns a limiter waiting for each of the given limiters in
+// turn. If a limiter fails, the tokens already taken from the previous
+// *TokenBucket limiters are given back.
+func MultiLimiter(limiters ...Limiter) Limiter {
+	return multiLimiter(limiters)
+}
+
+type multiLimiter []Limiter
+
+func (ml multiLimiter) Wait(ctx context.Context) error {
+	for i, l := range ml {
+		err := l.Wait(ctx)
+		if err != nil {
+			for _, prev := range ml[:i] {
+				if tb, ok := prev.(*TokenBucket); ok {
+					tb.release()
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// LimitTransport returns a round tripper waiting for the given limiter before
+// performing each request with the given base round tripper.
+// http.DefaultTransport is used if base is nil and base itself is returned if
+// the limiter is nil.
+//
+// When combined with a retry policy, it must be placed below it so that every
+// attempt waits for the limiter. The errors of the limiter are marked so that
+// the retry policy does not retry them.
+func LimitTransport(limiter Limiter, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if limiter == nil {
+		return base
+	}
+	return &limitTransport{limiter: limiter, base: base}
+}
+
+type limitTransport struct {
+	limiter Limiter
+	base    http.RoundTripper
+}
+
+func (lt *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	err := lt.limiter.Wait(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, httputil.Permanent(err)
+	}
+	return lt.base.RoundTrip(req)
+}
@@ -0,0 +1,109 @@
+package naverapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi"
+)
+
+func TestTokenBucket_Wait(t *testing.T) {
+	tb := naverapi.NewTokenBucket(2, 100*time.Millisecond)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		err := tb.Wait(context.Background())
+		if err != nil {
+			t.Fatalf("waiting for the token bucket shouldn't fail but got: %v", err)
+		}
+	}
+
+	// The two first requests use the initial burst, the third one has to wait
+	// for a token to be refilled.
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected to wait for a token to be refilled but only waited %v", elapsed)
+	}
+}
+
+func TestTokenBucket_Wait_ShouldFailIfDeadlineWouldBeExceeded(t *testing.T) {
+	tb := naverapi.NewTokenBucket(1, time.Hour)
+
+	err := tb.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("waiting for the token bucket shouldn't fail but got: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err = tb.Wait(ctx)
+	if !errors.Is(err, naverapi.ErrRateLimitWaitExceedsDeadline) {
+		t.Errorf("expected error %v but got: %v", naverapi.ErrRateLimitWaitExceedsDeadline, err)
+	}
+}
+
+func TestTokenBucket_Wait_ShouldFailIfContextCanceled(t *testing.T) {
+	tb := naverapi.NewTokenBucket(1, time.Hour)
+
+	err := tb.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("waiting for the token bucket shouldn't fail but got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err = tb.Wait(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error %v but got: %v", context.Canceled, err)
+	}
+}
+
+func TestMultiLimiter_Wait_ShouldGiveBackTheTokensOnFailure(t *testing.T) {
+	perSecond := naverapi.NewTokenBucket(1, time.Hour)
+	perDay := naverapi.NewTokenBucket(0, time.Hour)
+
+	err := naverapi.MultiLimiter(perSecond, perDay).Wait(context.Background())
+	if !errors.Is(err, naverapi.ErrEmptyTokenBucket) {
+		t.Fatalf("expected error %v but got: %v", naverapi.ErrEmptyTokenBucket, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err = perSecond.Wait(ctx)
+	if err != nil {
+		t.Errorf("expected the token taken before the failure to be given back but got: %v", err)
+	}
+}
+
+type failingLimiter struct {
+	calls int
+}
+
+func (fl *failingLimiter) Wait(ctx context.Context) error {
+	fl.calls++
+	return naverapi.ErrRateLimitWaitExceedsDeadline
+}
+
+func TestLimitTransport_ShouldNotBeRetried(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	limiter := &failingLimiter{}
+	client := srv.Client()
+	client.Transport = testRetryPolicy.Transport(naverapi.LimitTransport(limiter, client.Transport))
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+	_, err := client.Do(req)
+	if !errors.Is(err, naverapi.ErrRateLimitWaitExceedsDeadline) {
+		t.Fatalf("expected error %v but got: %v", naverapi.ErrRateLimitWaitExceedsDeadline, err)
+	}
+	if limiter.calls != 1 {
+		t.Errorf("expected the limiter to be waited for once but it was %d times", limiter.calls)
+	}
+}
@@ -0,0 +1,284 @@
+package naverapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/connectfit-team/naverapi/internal/httputil"
+)
+
+const (
+	// maxDrainSize is the maximum number of bytes read from the body of a
+	// response before retrying, so that the underlying connection can be
+	// reused.
+	maxDrainSize = 4 << 10
+	// DefaultMaxRetryAfter is the maximum time waited because of a
+	// `Retry-After` header by the policies without MaxBackoff.
+	DefaultMaxRetryAfter = time.Minute
+)
+
+var (
+	// DefaultRetryPolicy is the retry policy used by the clients unless
+	// configured otherwise.
+	DefaultRetryPolicy = RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  200 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+	}
+	// NoRetry is a retry policy sending each request only once.
+	// Use it for clients performing non-idempotent requests which must not be
+	// sent twice.
+	NoRetry = RetryPolicy{
+		MaxAttempts: 1,
+	}
+)
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec // Not used for security purposes.
+)
+
+// RetryPolicy describes how failed requests are retried.
+//
+// An idempotent request (GET, HEAD, OPTIONS, PUT or DELETE) is retried when
+// it failed because of a network error or when the API answered with a
+// retryable status (see APIError.IsRetryable).
+// Since the API may already have accepted the other requests, such as the
+// ones sending a message, they are only retried when the connection to the
+// API could not be made or when the API answered with the 429 or 503 status.
+// The errors of the limiter (see LimitTransport) are never retried.
+//
+// The time between two attempts grows exponentially from MinBackoff up to
+// MaxBackoff with a random jitter, unless the API asks to wait longer with a
+// `Retry-After` header. The wait required by the API is capped at MaxBackoff,
+// or DefaultMaxRetryAfter if MaxBackoff is not set.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent, the first
+	// attempt included. A value lower or equal to 1 disables the retries.
+	MaxAttempts int
+	// MinBackoff is the base waiting time before the first retry.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum waiting time between two attempts, including
+	// the one required by a `Retry-After` header.
+	MaxBackoff time.Duration
+	// Sleep, if set, replaces the wait between two attempts.
+	// It has been made public mainly for testing purpose, to avoid waiting
+	// for real.
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+// Backoff returns the time to wait before sending the given attempt, the
+// first retry being the attempt 1.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 || p.MinBackoff <= 0 {
+		return 0
+	}
+
+	backoff := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || backoff < p.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	// Equal jitter: wait at least half of the backoff.
+	half := int64(backoff / 2)
+	jitterMu.Lock()
+	jitter := jitterRand.Int63n(half + 1)
+	jitterMu.Unlock()
+
+	return time.Duration(half + jitter)
+}
+
+// Transport returns a round tripper performing the requests with the given
+// base round tripper and retrying them according to the policy.
+// http.DefaultTransport is used if base is nil.
+//
+// Every attempt goes through the base round tripper again, so a signing
+// transport placed below it computes a fresh timestamp and signature for
+// each of them.
+func (p RetryPolicy) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if p.MaxAttempts <= 1 {
+		return base
+	}
+	return &retryTransport{policy: p, base: base}
+}
+
+// Client returns a shallow copy of the given HTTP client whose transport
+// retries the requests according to the policy.
+// http.DefaultClient is used as a base if the given client is nil.
+func (p RetryPolicy) Client(client *http.Client) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	c := *client
+	c.Transport = p.Transport(client.Transport)
+
+	return &c
+}
+
+type retryTransport struct {
+	policy RetryPolicy
+	base   http.RoundTripper
+}
+
+func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			attemptReq, err = rewindRequest(req)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := rt.base.RoundTrip(attemptReq)
+		if !rt.shouldRetry(ctx, attempt, req, resp, err) {
+			return resp, err
+		}
+
+		wait := rt.policy.Backoff(attempt + 1)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok && retryAfter > wait {
+				wait = retryAfter
+				if limit := rt.policy.maxRetryAfter(); wait > limit {
+					wait = limit
+				}
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			// No point in waiting if the request could not be sent again
+			// before the deadline.
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.CopyN(io.Discard, resp.Body, maxDrainSize)
+			resp.Body.Close()
+		}
+
+		if rt.policy.Sleep != nil {
+			err = rt.policy.Sleep(ctx, wait)
+		} else {
+			err = sleep(ctx, wait)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p RetryPolicy) maxRetryAfter() time.Duration {
+	if p.MaxBackoff > 0 {
+		return p.MaxBackoff
+	}
+	return DefaultMaxRetryAfter
+}
+
+func (rt *retryTransport) shouldRetry(ctx context.Context, attempt int, req *http.Request, resp *http.Response, err error) bool {
+	if attempt+1 >= rt.policy.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body can't be sent again.
+		return false
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || httputil.IsPermanent(err) {
+			return false
+		}
+		return isIdempotent(req) || isDialError(err)
+	}
+	if !isIdempotent(req) {
+		return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+	}
+	return isRetryableStatus(resp.StatusCode)
+}
+
+// isIdempotent reports whether sending the given request several times has
+// the same effect as sending it once.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isDialError reports whether the given error happened before the connection
+// to the server was made, so that the request could not have been received.
+func isDialError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// rewindRequest returns a copy of the given request with a fresh body.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil && req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+// parseRetryAfter parses the value of a `Retry-After` header which is either
+// a number of seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(v); err == nil {
+		d := date.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,252 @@
+package naverapi_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/connectfit-team/naverapi/internal/httputil"
+)
+
+var testRetryPolicy = naverapi.RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  2 * time.Millisecond,
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := naverapi.RetryPolicy{
+		MaxAttempts: 10,
+		MinBackoff:  100 * time.Millisecond,
+		MaxBackoff:  time.Second,
+	}
+	tests := []struct {
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{attempt: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{attempt: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{attempt: 8, min: 500 * time.Millisecond, max: time.Second},
+	}
+	for _, tt := range tests {
+		got := policy.Backoff(tt.attempt)
+		if got < tt.min || got > tt.max {
+			t.Errorf("Backoff(%d) = %v, want between %v and %v", tt.attempt, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestRetryPolicy_Client_ShouldRetryRetryableStatus(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "test-body" {
+			t.Errorf("attempt %d: expected body %q but got %q", attempts, "test-body", body)
+		}
+
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL, strings.NewReader("test-body"))
+	resp, err := testRetryPolicy.Client(srv.Client()).Do(req)
+	if err != nil {
+		t.Fatalf("request should succeed after retrying but got: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("expected status %d but got %d", http.StatusAccepted, resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts but got %d", attempts)
+	}
+}
+
+func TestRetryPolicy_Client_ShouldHonourRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		maxBackoff time.Duration
+		wantWait   time.Duration
+	}{
+		{name: "longer than the backoff", retryAfter: "2", maxBackoff: 5 * time.Second, wantWait: 2 * time.Second},
+		{name: "capped at the max backoff", retryAfter: "86400", maxBackoff: 5 * time.Second, wantWait: 5 * time.Second},
+		{name: "capped without max backoff", retryAfter: "86400", wantWait: naverapi.DefaultMaxRetryAfter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					w.WriteHeader(http.StatusTooManyRequests)
+				}
+			}))
+			defer srv.Close()
+
+			var waits []time.Duration
+			policy := naverapi.RetryPolicy{
+				MaxAttempts: 2,
+				MinBackoff:  time.Millisecond,
+				MaxBackoff:  tt.maxBackoff,
+				Sleep: func(ctx context.Context, d time.Duration) error {
+					waits = append(waits, d)
+					return nil
+				},
+			}
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+			resp, err := policy.Client(srv.Client()).Do(req)
+			if err != nil {
+				t.Fatalf("request should succeed after retrying but got: %v", err)
+			}
+			resp.Body.Close()
+
+			if len(waits) != 1 || waits[0] != tt.wantWait {
+				t.Errorf("expected to wait %v before retrying but waited %v", tt.wantWait, waits)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Client_ShouldNotRetryNonIdempotentRequestsOnServerErrors(t *testing.T) {
+	tests := []struct {
+		status       int
+		wantAttempts int
+	}{
+		{status: http.StatusInternalServerError, wantAttempts: 1},
+		{status: http.StatusBadGateway, wantAttempts: 1},
+		{status: http.StatusGatewayTimeout, wantAttempts: 1},
+		{status: http.StatusTooManyRequests, wantAttempts: 3},
+		{status: http.StatusServiceUnavailable, wantAttempts: 3},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			var attempts int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL, strings.NewReader("test-body"))
+			resp, err := testRetryPolicy.Client(srv.Client()).Do(req)
+			if err != nil {
+				t.Fatalf("request shouldn't fail but got: %v", err)
+			}
+			resp.Body.Close()
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("expected %d attempts but got %d", tt.wantAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Client_ShouldRetryNonIdempotentRequestsOnlyBeforeConnecting(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		// Close the connection once the request has been received.
+		hj, _ := w.(http.Hijacker)
+		conn, _, _ := hj.Hijack()
+		conn.Close()
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL, strings.NewReader("test-body"))
+	_, err := testRetryPolicy.Client(srv.Client()).Do(req)
+	if err == nil {
+		t.Fatal("request should fail")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("expected a single attempt once the request was sent but got %d", got)
+	}
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	var waits int
+	policy := testRetryPolicy
+	policy.Sleep = func(ctx context.Context, d time.Duration) error {
+		waits++
+		return nil
+	}
+	req, _ = http.NewRequestWithContext(context.Background(), http.MethodPost, closed.URL, strings.NewReader("test-body"))
+	_, err = policy.Client(closed.Client()).Do(req)
+	if err == nil {
+		t.Fatal("request should fail")
+	}
+	if waits != 2 {
+		t.Errorf("expected the request to be retried twice when the connection failed but it was retried %d times", waits)
+	}
+}
+
+func TestRetryPolicy_Client_ShouldNotRetryOtherStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy naverapi.RetryPolicy
+		status int
+	}{
+		{name: "client error", policy: testRetryPolicy, status: http.StatusBadRequest},
+		{name: "retries disabled", policy: naverapi.NoRetry, status: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+			resp, err := tt.policy.Client(srv.Client()).Do(req)
+			if err != nil {
+				t.Fatalf("request shouldn't fail but got: %v", err)
+			}
+			resp.Body.Close()
+
+			if attempts != 1 {
+				t.Errorf("expected a single attempt but got %d", attempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Client_ShouldNotRetrySigningErrors(t *testing.T) {
+	var resolutions int
+	signer := httputil.Signer{
+		Credentials: func(ctx context.Context) (string, string, error) {
+			resolutions++
+			return "", "", naverapi.ErrNoCredentials
+		},
+	}
+	client := testRetryPolicy.Client(httputil.NewSignedClient(nil, signer))
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://localhost/", nil)
+	_, err := client.Do(req)
+	if !errors.Is(err, naverapi.ErrNoCredentials) {
+		t.Fatalf("expected error %v but got: %v", naverapi.ErrNoCredentials, err)
+	}
+	if resolutions != 1 {
+		t.Errorf("expected the credentials to be resolved once but they were %d times", resolutions)
+	}
+}
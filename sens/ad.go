@@ -0,0 +1,243 @@
+package sens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// AdPrefix is the prefix advertisements must start with.
+	AdPrefix = "(광고)"
+	// DefaultAdFooterFormat is the default format of the opt-out footer
+	// appended to the advertisements, given the opt-out number.
+	DefaultAdFooterFormat = "\n무료거부 %s"
+	// AdQuietHoursStart is the hour (KST) from which advertisements can't be
+	// sent without the consent of their recipients.
+	AdQuietHoursStart = 21
+	// AdQuietHoursEnd is the hour (KST) until which advertisements can't be
+	// sent without the consent of their recipients.
+	AdQuietHoursEnd = 8
+)
+
+// ErrAdNotCompliant is wrapped by the *AdComplianceError returned when an
+// advertisement can't be made compliant.
+var ErrAdNotCompliant = errors.New("the advertisement is not compliant")
+
+// QuietHoursAction is what is done with the advertisements sent during the
+// quiet hours.
+type QuietHoursAction int
+
+const (
+	// QuietHoursRefuse makes the advertisements sent during the quiet hours
+	// fail with an AdViolationQuietHours violation.
+	QuietHoursRefuse QuietHoursAction = iota
+	// QuietHoursReschedule reserves the advertisements sent during the quiet
+	// hours for their end.
+	QuietHoursReschedule
+)
+
+// AdViolationReason is the reason why an advertisement is not compliant.
+type AdViolationReason string
+
+const (
+	AdViolationMissingOptOutNumber AdViolationReason = "missing_opt_out_number"
+	AdViolationQuietHours          AdViolationReason = "quiet_hours"
+	AdViolationInvalidContent      AdViolationReason = "invalid_content"
+	AdViolationInvalidReserveTime  AdViolationReason = "invalid_reserve_time"
+)
+
+// AdViolation is a rule an advertisement breaks.
+type AdViolation struct {
+	// Reason is the rule the advertisement breaks.
+	Reason AdViolationReason
+	// Detail describes the violation.
+	Detail string
+}
+
+// AdComplianceError is returned when an advertisement can't be made
+// compliant.
+type AdComplianceError struct {
+	// Violations are the rules broken by the advertisement.
+	Violations []AdViolation
+}
+
+// Error implements the error interface.
+func (e *AdComplianceError) Error() string {
+	details := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		details[i] = fmt.Sprintf("%s: %s", v.Reason, v.Detail)
+	}
+	return fmt.Sprintf("%v: %s", ErrAdNotCompliant, strings.Join(details, "; "))
+}
+
+// Unwrap returns ErrAdNotCompliant.
+func (e *AdComplianceError) Unwrap() error {
+	return ErrAdNotCompliant
+}
+
+// AdPolicy makes the advertisements (ContentTypeAD) comply with the Korean
+// regulations: they start with AdPrefix, end with a free opt-out number and
+// aren't sent between AdQuietHoursStart and AdQuietHoursEnd KST unless their
+// recipients consented to it.
+type AdPolicy struct {
+	// OptOutNumber is the free number (080) the recipients can call to opt
+	// out. It is required.
+	OptOutNumber string
+	// FooterFormat is the format of the footer appended to the content
+	// given the opt-out number. Defaults to DefaultAdFooterFormat.
+	FooterFormat string
+	// QuietHours is what is done with the advertisements sent during the
+	// quiet hours.
+	QuietHours QuietHoursAction
+	// NightConsent reports whether the recipients consented to receive
+	// advertisements during the quiet hours.
+	NightConsent bool
+}
+
+// Apply returns a copy of the given advertisement made compliant, as if sent
+// at the given time: the prefix and the opt-out footer are added to the
+// contents not having them yet, the type of SMS messages is changed to LMS if
+// they become too long and the request is reserved for the end of the quiet
+// hours if it is sent during them and the policy reschedules them.
+// Requests which are not advertisements are returned as is.
+// It returns an *AdComplianceError if the advertisement can't be made
+// compliant.
+func (p AdPolicy) Apply(req SendSMSRequest, now time.Time) (SendSMSRequest, error) {
+	if req.ContentType != ContentTypeAD {
+		return req, nil
+	}
+
+	var violations []AdViolation
+	if p.OptOutNumber == "" {
+		violations = append(violations, AdViolation{
+			Reason: AdViolationMissingOptOutNumber,
+			Detail: "the policy has no opt-out number",
+		})
+	}
+
+	req.Content = p.decorate(req.Content)
+	req.Messages = append([]Message(nil), req.Messages...)
+	for i, msg := range req.Messages {
+		if msg.Content != "" {
+			req.Messages[i].Content = p.decorate(msg.Content)
+		}
+	}
+
+	if req.Type == SMSTypeSMS || req.Type == SMSTypeLMS || req.Type == SMSTypeAuto {
+		typ, err := SelectSMSType(req)
+		if err != nil {
+			violations = append(violations, AdViolation{
+				Reason: AdViolationInvalidContent,
+				Detail: err.Error(),
+			})
+		} else if typ == SMSTypeLMS || req.Type == SMSTypeAuto {
+			req.Type = typ
+		}
+	}
+
+	if !p.NightConsent {
+		sendTime, err := req.ReservedAt()
+		if err != nil {
+			violations = append(violations, AdViolation{
+				Reason: AdViolationInvalidReserveTime,
+				Detail: err.Error(),
+			})
+		} else if sendTime.IsZero() {
+			sendTime = now
+		}
+
+		if err == nil && inAdQuietHours(sendTime) {
+			if p.QuietHours == QuietHoursReschedule {
+				req, err = req.ReserveAt(adQuietHoursEnd(sendTime))
+				if err != nil {
+					violations = append(violations, AdViolation{
+						Reason: AdViolationInvalidReserveTime,
+						Detail: err.Error(),
+					})
+				}
+			} else {
+				violations = append(violations, AdViolation{
+					Reason: AdViolationQuietHours,
+					Detail: fmt.Sprintf("advertisements can't be sent at %s", sendTime.In(KST).Format("15:04 MST")),
+				})
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return SendSMSRequest{}, &AdComplianceError{Violations: violations}
+	}
+	return req, nil
+}
+
+// decorate adds the prefix and the opt-out footer to the given content, if
+// not present yet.
+func (p AdPolicy) decorate(content string) string {
+	if content == "" {
+		return content
+	}
+	if !strings.HasPrefix(content, AdPrefix) {
+		content = AdPrefix + content
+	}
+	if p.OptOutNumber != "" && !containsNumber(content, p.OptOutNumber) {
+		format := p.FooterFormat
+		if format == "" {
+			format = DefaultAdFooterFormat
+		}
+		content += fmt.Sprintf(format, p.OptOutNumber)
+	}
+	return content
+}
+
+// containsNumber reports whether the given content holds the given phone
+// number, whatever their separators, e.g. 080-123-4567 for 0801234567.
+// The number must stand on its own: it is not found inside a longer one.
+func containsNumber(content, number string) bool {
+	want := digits(number)
+	if want == "" {
+		return false
+	}
+
+	var run strings.Builder
+	for _, r := range content + "\n" {
+		switch {
+		case r >= '0' && r <= '9':
+			run.WriteRune(r)
+		case r == '-' || r == '.' || r == ' ' || r == '(' || r == ')':
+		default:
+			if run.String() == want {
+				return true
+			}
+			run.Reset()
+		}
+	}
+	return false
+}
+
+// digits returns the digits of the given string.
+func digits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func inAdQuietHours(t time.Time) bool {
+	hour := t.In(KST).Hour()
+	return hour >= AdQuietHoursStart || hour < AdQuietHoursEnd
+}
+
+// adQuietHoursEnd returns the end of the quiet hours t is in.
+func adQuietHoursEnd(t time.Time) time.Time {
+	kst := t.In(KST)
+	end := time.Date(kst.Year(), kst.Month(), kst.Day(), AdQuietHoursEnd, 0, 0, 0, KST)
+	if kst.Hour() >= AdQuietHoursStart {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
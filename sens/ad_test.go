@@ -0,0 +1,249 @@
+package sens_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAdPolicy_Apply(t *testing.T) {
+	policy := sens.AdPolicy{OptOutNumber: "0801234567"}
+	day := time.Date(2023, 3, 2, 10, 15, 0, 0, sens.KST)
+
+	tests := []struct {
+		name string
+		req  sens.SendSMSRequest
+		want sens.SendSMSRequest
+	}{
+		{
+			name: "not an advertisement",
+			req:  sens.SendSMSRequest{Type: sens.SMSTypeSMS, ContentType: sens.ContentTypeSMS, Content: "hello"},
+			want: sens.SendSMSRequest{Type: sens.SMSTypeSMS, ContentType: sens.ContentTypeSMS, Content: "hello"},
+		},
+		{
+			name: "prefix and footer",
+			req: sens.SendSMSRequest{
+				Type:        sens.SMSTypeSMS,
+				ContentType: sens.ContentTypeAD,
+				Content:     "sale",
+				Messages:    []sens.Message{{To: "01012345678"}, {To: "01087654321", Content: "vip sale"}},
+			},
+			want: sens.SendSMSRequest{
+				Type:        sens.SMSTypeSMS,
+				ContentType: sens.ContentTypeAD,
+				Content:     "(광고)sale\n무료거부 0801234567",
+				Messages:    []sens.Message{{To: "01012345678"}, {To: "01087654321", Content: "(광고)vip sale\n무료거부 0801234567"}},
+			},
+		},
+		{
+			name: "already compliant",
+			req:  sens.SendSMSRequest{Type: sens.SMSTypeSMS, ContentType: sens.ContentTypeAD, Content: "(광고)sale 무료거부 0801234567"},
+			want: sens.SendSMSRequest{Type: sens.SMSTypeSMS, ContentType: sens.ContentTypeAD, Content: "(광고)sale 무료거부 0801234567"},
+		},
+		{
+			name: "SMS becoming too long",
+			req:  sens.SendSMSRequest{Type: sens.SMSTypeSMS, ContentType: sens.ContentTypeAD, Content: strings.Repeat("a", 70)},
+			want: sens.SendSMSRequest{Type: sens.SMSTypeLMS, ContentType: sens.ContentTypeAD, Content: "(광고)" + strings.Repeat("a", 70) + "\n무료거부 0801234567"},
+		},
+		{
+			name: "auto type",
+			req:  sens.SendSMSRequest{Type: sens.SMSTypeAuto, ContentType: sens.ContentTypeAD, Content: "sale"},
+			want: sens.SendSMSRequest{Type: sens.SMSTypeSMS, ContentType: sens.ContentTypeAD, Content: "(광고)sale\n무료거부 0801234567"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policy.Apply(tt.req, day)
+			if err != nil {
+				t.Fatalf("Apply was given a valid request but failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Unexpected request (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAdPolicy_Apply_QuietHours(t *testing.T) {
+	req := sens.SendSMSRequest{Type: sens.SMSTypeSMS, ContentType: sens.ContentTypeAD, Content: "sale"}
+
+	tests := []struct {
+		name            string
+		policy          sens.AdPolicy
+		now             time.Time
+		reserveAt       time.Time
+		wantReserveTime string
+		wantViolation   bool
+	}{
+		{name: "day", policy: sens.AdPolicy{OptOutNumber: "0801234567"}, now: time.Date(2023, 3, 2, 20, 59, 0, 0, sens.KST)},
+		{name: "refused at night", policy: sens.AdPolicy{OptOutNumber: "0801234567"}, now: time.Date(2023, 3, 2, 21, 0, 0, 0, sens.KST), wantViolation: true},
+		{name: "refused in the morning", policy: sens.AdPolicy{OptOutNumber: "0801234567"}, now: time.Date(2023, 3, 2, 7, 59, 0, 0, sens.KST), wantViolation: true},
+		{name: "refused when reserved at night", policy: sens.AdPolicy{OptOutNumber: "0801234567"}, now: time.Date(2023, 3, 2, 10, 0, 0, 0, sens.KST), reserveAt: time.Date(2023, 3, 2, 23, 0, 0, 0, sens.KST), wantViolation: true},
+		{name: "consent", policy: sens.AdPolicy{OptOutNumber: "0801234567", NightConsent: true}, now: time.Date(2023, 3, 2, 23, 0, 0, 0, sens.KST)},
+		{name: "rescheduled before midnight", policy: sens.AdPolicy{OptOutNumber: "0801234567", QuietHours: sens.QuietHoursReschedule}, now: time.Date(2023, 3, 2, 23, 0, 0, 0, sens.KST), wantReserveTime: "2023-03-03 08:00"},
+		{name: "rescheduled after midnight", policy: sens.AdPolicy{OptOutNumber: "0801234567", QuietHours: sens.QuietHoursReschedule}, now: time.Date(2023, 3, 2, 16, 0, 0, 0, time.UTC), wantReserveTime: "2023-03-03 08:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := req
+			if !tt.reserveAt.IsZero() {
+				var err error
+				r, err = r.ReserveAt(tt.reserveAt)
+				if err != nil {
+					t.Fatalf("ReserveAt was given a valid time but failed: %v", err)
+				}
+			}
+
+			got, err := tt.policy.Apply(r, tt.now)
+			if tt.wantViolation {
+				var complianceErr *sens.AdComplianceError
+				if !errors.As(err, &complianceErr) {
+					t.Fatalf("Expected an *AdComplianceError but got: %v", err)
+				}
+				want := []sens.AdViolationReason{sens.AdViolationQuietHours}
+				if diff := cmp.Diff(want, reasons(complianceErr)); diff != "" {
+					t.Errorf("Unexpected violations (-want +got):\n%s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply was given a valid request but failed: %v", err)
+			}
+			if got.ReserveTime != tt.wantReserveTime {
+				t.Errorf("Expected reserve time %q but got %q", tt.wantReserveTime, got.ReserveTime)
+			}
+		})
+	}
+}
+
+func TestAdPolicy_Apply_ShouldReportEveryViolation(t *testing.T) {
+	req := sens.SendSMSRequest{Type: sens.SMSTypeLMS, ContentType: sens.ContentTypeAD, Content: "sale 🎉"}
+
+	_, err := sens.AdPolicy{}.Apply(req, time.Date(2023, 3, 2, 22, 0, 0, 0, sens.KST))
+	if !errors.Is(err, sens.ErrAdNotCompliant) {
+		t.Fatalf("Expected error %v but got: %v", sens.ErrAdNotCompliant, err)
+	}
+
+	var complianceErr *sens.AdComplianceError
+	if !errors.As(err, &complianceErr) {
+		t.Fatalf("Expected an *AdComplianceError but got: %v", err)
+	}
+	want := []sens.AdViolationReason{
+		sens.AdViolationMissingOptOutNumber,
+		sens.AdViolationInvalidContent,
+		sens.AdViolationQuietHours,
+	}
+	if diff := cmp.Diff(want, reasons(complianceErr)); diff != "" {
+		t.Errorf("Unexpected violations (-want +got):\n%s", diff)
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldApplyTheAdPolicy(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.AdPolicy = &sens.AdPolicy{OptOutNumber: "0801234567", QuietHours: sens.QuietHoursReschedule}
+
+	var got sens.SendSMSRequest
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("Could not decode the request body: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusName":"success"}`)
+	})
+
+	// The client clock is fixed to 1997-02-26 09:00 KST.
+	req, err := sens.SendSMSRequest{
+		Type:        sens.SMSTypeSMS,
+		ContentType: sens.ContentTypeAD,
+		Content:     "sale",
+		Messages:    []sens.Message{{To: "01012345678"}},
+	}.ReserveAt(time.Date(1997, 2, 26, 22, 30, 0, 0, sens.KST))
+	if err != nil {
+		t.Fatalf("ReserveAt was given a valid time but failed: %v", err)
+	}
+
+	_, err = client.SendSMS(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Send SMS request was given a valid request but failed: %v", err)
+	}
+
+	if want := "(광고)sale\n무료거부 0801234567"; got.Content != want {
+		t.Errorf("Expected content %q but got %q", want, got.Content)
+	}
+	if want := "1997-02-27 08:00"; got.ReserveTime != want {
+		t.Errorf("Expected reserve time %q but got %q", want, got.ReserveTime)
+	}
+}
+
+func reasons(err *sens.AdComplianceError) []sens.AdViolationReason {
+	var reasons []sens.AdViolationReason
+	for _, v := range err.Violations {
+		reasons = append(reasons, v.Reason)
+	}
+	return reasons
+}
+
+func TestAdPolicy_Apply_ShouldRecognizeTheOptOutNumberWhateverItsFormat(t *testing.T) {
+	policy := sens.AdPolicy{OptOutNumber: "0801234567"}
+
+	for _, content := range []string{
+		"(광고)sale 무료거부 080-123-4567",
+		"(광고)sale 무료거부 080 123 4567",
+		"(광고)sale 무료거부 (080) 123-4567",
+	} {
+		req := sens.SendSMSRequest{Type: sens.SMSTypeLMS, ContentType: sens.ContentTypeAD, Content: content}
+		got, err := policy.Apply(req, time.Date(2023, 3, 2, 10, 15, 0, 0, sens.KST))
+		if err != nil {
+			t.Fatalf("Apply was given a valid request but failed: %v", err)
+		}
+		if got.Content != content {
+			t.Errorf("Expected %q to be left as is but got %q", content, got.Content)
+		}
+	}
+
+	req := sens.SendSMSRequest{Type: sens.SMSTypeLMS, ContentType: sens.ContentTypeAD, Content: "(광고)sale 080-123-4568"}
+	got, err := policy.Apply(req, time.Date(2023, 3, 2, 10, 15, 0, 0, sens.KST))
+	if err != nil {
+		t.Fatalf("Apply was given a valid request but failed: %v", err)
+	}
+	if want := "(광고)sale 080-123-4568\n무료거부 0801234567"; got.Content != want {
+		t.Errorf("Expected content %q but got %q", want, got.Content)
+	}
+
+	policy = sens.AdPolicy{OptOutNumber: "080-123-4567"}
+	req = sens.SendSMSRequest{Type: sens.SMSTypeLMS, ContentType: sens.ContentTypeAD, Content: "쿠폰 코드 9080123456789"}
+	got, err = policy.Apply(req, time.Date(2023, 3, 2, 10, 15, 0, 0, sens.KST))
+	if err != nil {
+		t.Fatalf("Apply was given a valid request but failed: %v", err)
+	}
+	if want := "\n무료거부 080-123-4567"; !strings.HasSuffix(got.Content, want) {
+		t.Errorf("Expected the content to end with %q but got %q", want, got.Content)
+	}
+}
+
+func TestAdPolicy_Apply_ShouldReportAnInvalidReserveTime(t *testing.T) {
+	req := sens.SendSMSRequest{Type: sens.SMSTypeLMS, ContentType: sens.ContentTypeAD, Content: "sale", ReserveTime: "tomorrow"}
+
+	_, err := sens.AdPolicy{OptOutNumber: "0801234567"}.Apply(req, time.Date(2023, 3, 2, 10, 15, 0, 0, sens.KST))
+	var complianceErr *sens.AdComplianceError
+	if !errors.As(err, &complianceErr) {
+		t.Fatalf("Expected an *AdComplianceError but got: %v", err)
+	}
+	want := []sens.AdViolationReason{sens.AdViolationInvalidReserveTime}
+	if diff := cmp.Diff(want, reasons(complianceErr)); diff != "" {
+		t.Errorf("Unexpected violations (-want +got):\n%s", diff)
+	}
+	if !errors.Is(err, sens.ErrAdNotCompliant) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrAdNotCompliant, err)
+	}
+}
@@ -0,0 +1,312 @@
+package sens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/connectfit-team/naverapi"
+)
+
+const (
+	EndpointAlimTalkAPI      = "/alimtalk/v2"
+	EndpointAlimTalkServices = EndpointAlimTalkAPI + "/services"
+)
+
+var (
+	// ErrSendAlimTalkFailed is wrapped in the *naverapi.APIError returned
+	// when the `statusName` in the response after requesting to send
+	// AlimTalk messages is not "success".
+	ErrSendAlimTalkFailed = errors.New(`the send AlimTalk request's response did not return status "success"`)
+	// ErrInvalidButton is returned when a button of a message lacks the links
+	// required by its type.
+	ErrInvalidButton = errors.New("invalid button")
+)
+
+// TemplateCode is the code of an AlimTalk template registered in the SENS
+// console.
+type TemplateCode string
+
+// ButtonType is the type of a KakaoTalk message button.
+type ButtonType string
+
+const (
+	ButtonTypeDeliveryTracking ButtonType = "DS" // 배송 조회
+	ButtonTypeWebLink          ButtonType = "WL" // 웹 링크
+	ButtonTypeAppLink          ButtonType = "AL" // 앱 링크
+	ButtonTypeBotKeyword       ButtonType = "BK" // 봇 키워드
+	ButtonTypeMessageDelivery  ButtonType = "MD" // 메시지 전달
+)
+
+// Button is a button of a KakaoTalk message. The buttons of an AlimTalk
+// message must match the ones of its template.
+type Button struct {
+	Type          ButtonType `json:"type"`                    // 버튼 타입 - 필수
+	Name          string     `json:"name"`                    // 버튼명 - 필수
+	LinkMobile    string     `json:"linkMobile,omitempty"`    // 모바일 웹 링크(WL 필수) - 선택
+	LinkPC        string     `json:"linkPc,omitempty"`        // PC 웹 링크 - 선택
+	SchemeIOS     string     `json:"schemeIos,omitempty"`     // iOS 앱 링크(AL 필수) - 선택
+	SchemeAndroid string     `json:"schemeAndroid,omitempty"` // Android 앱 링크(AL 필수) - 선택
+}
+
+// WebLinkButton returns a button opening the given web pages.
+func WebLinkButton(name, linkMobile, linkPC string) Button {
+	return Button{Type: ButtonTypeWebLink, Name: name, LinkMobile: linkMobile, LinkPC: linkPC}
+}
+
+// AppLinkButton returns a button opening the given app schemes.
+func AppLinkButton(name, schemeIOS, schemeAndroid string) Button {
+	return Button{Type: ButtonTypeAppLink, Name: name, SchemeIOS: schemeIOS, SchemeAndroid: schemeAndroid}
+}
+
+// DeliveryTrackingButton returns a button opening the delivery tracking page
+// of the parcel mentioned in the message.
+func DeliveryTrackingButton(name string) Button {
+	return Button{Type: ButtonTypeDeliveryTracking, Name: name}
+}
+
+// Validate checks that the button has the links required by its type.
+func (b Button) Validate() error {
+	switch {
+	case b.Name == "":
+		return fmt.Errorf("%w: the %s button has no name", ErrInvalidButton, b.Type)
+	case b.Type == ButtonTypeWebLink && b.LinkMobile == "":
+		return fmt.Errorf("%w: the %q web link button has no mobile link", ErrInvalidButton, b.Name)
+	case b.Type == ButtonTypeAppLink && (b.SchemeIOS == "" || b.SchemeAndroid == ""):
+		return fmt.Errorf("%w: the %q app link button needs both an iOS and an Android scheme", ErrInvalidButton, b.Name)
+	}
+	return nil
+}
+
+// FailoverConfig describes the SMS sent instead of an AlimTalk message which
+// could not be delivered. The content of the AlimTalk message is used when
+// Content is empty.
+type FailoverConfig struct {
+	Type    SMSType `json:"type,omitempty"`    // 대체 문자 타입 (SMS | LMS) - 선택
+	From    string  `json:"from,omitempty"`    // 대체 문자 발신 번호 - 선택
+	Subject string  `json:"subject,omitempty"` // 대체 문자 제목(LMS 만 사용) - 선택
+	Content string  `json:"content,omitempty"` // 대체 문자 내용 - 선택
+}
+
+// AlimTalkItem is a line of the item list of an AlimTalk message.
+type AlimTalkItem struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// AlimTalkItemList is the item list of an AlimTalk message.
+type AlimTalkItemList struct {
+	List    []AlimTalkItem `json:"list"`
+	Summary *AlimTalkItem  `json:"summary,omitempty"`
+}
+
+// AlimTalkMessage is an AlimTalk message sent to a single recipient.
+type AlimTalkMessage struct {
+	CountryCode    SMSCountryCode    `json:"countryCode,omitempty"`    // 국가 코드(default 82) - 선택
+	To             string            `json:"to"`                       // 수신자 번호 - 필수
+	Title          string            `json:"title,omitempty"`          // 강조 표기 제목 - 선택
+	Content        string            `json:"content"`                  // 템플릿 변수를 치환한 메시지 내용 - 필수
+	HeaderContent  string            `json:"headerContent,omitempty"`  // 헤더 내용 - 선택
+	ItemHighlight  *AlimTalkItem     `json:"itemHighlight,omitempty"`  // 아이템 하이라이트 - 선택
+	Item           *AlimTalkItemList `json:"item,omitempty"`           // 아이템 리스트 - 선택
+	Buttons        []Button          `json:"buttons,omitempty"`        // 버튼 정보 - 선택
+	UseSMSFailover bool              `json:"useSmsFailover,omitempty"` // 실패 시 대체 문자 발송 여부 - 선택
+	FailoverConfig *FailoverConfig   `json:"failoverConfig,omitempty"` // 대체 문자 정보 - 선택
+}
+
+// SendAlimTalkRequest represents the REST request to send AlimTalk messages.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+type SendAlimTalkRequest struct {
+	PlusFriendID    string            `json:"plusFriendId"`              // 카카오톡 채널명(@ 포함) - 필수
+	TemplateCode    TemplateCode      `json:"templateCode"`              // 템플릿 코드 - 필수
+	Messages        []AlimTalkMessage `json:"messages"`                  // 메시지 정보 - 필수
+	ReserveTime     string            `json:"reserveTime,omitempty"`     // 예약 시간("yyyy-MM-dd HH:mm", ReserveAt 으로 설정 가능) - 선택
+	ReserveTimeZone string            `json:"reserveTimezone,omitempty"` // 예약 시간 타임존("Asia/Seoul") - 선택
+	ScheduleCode    string            `json:"scheduleCode,omitempty"`
+}
+
+// KakaoMessageRequest is the acceptance of a message of a
+// SendAlimTalkRequest or a SendFriendTalkRequest.
+type KakaoMessageRequest struct {
+	MessageID         string         `json:"messageId"`
+	CountryCode       SMSCountryCode `json:"countryCode"`
+	To                string         `json:"to"`
+	Content           string         `json:"content"`
+	RequestStatusCode string         `json:"requestStatusCode"` // 요청 상태 코드 (A000: 성공)
+	RequestStatusName string         `json:"requestStatusName"` // 요청 상태 (success | fail)
+	RequestStatusDesc string         `json:"requestStatusDesc"` // 요청 상태 내용
+	UseSMSFailover    bool           `json:"useSmsFailover"`
+}
+
+// SendAlimTalkResponse represents the response sent by the SENS AlimTalk API
+// after a request to send AlimTalk messages.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+type SendAlimTalkResponse struct {
+	RequestID   string                `json:"requestId"`
+	RequestTime string                `json:"requestTime"`
+	StatusCode  string                `json:"statusCode"`
+	StatusName  string                `json:"statusName"`
+	Messages    []KakaoMessageRequest `json:"messages"`
+}
+
+// AlimTalkMessageResult represents the result of an AlimTalk message sent to
+// a single recipient.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+type AlimTalkMessageResult struct {
+	RequestID         string         `json:"requestId,omitempty"` // 메시지 조회 시에만 반환
+	MessageID         string         `json:"messageId"`
+	RequestTime       Time           `json:"requestTime"`
+	CountryCode       SMSCountryCode `json:"countryCode"`
+	To                string         `json:"to"`
+	PlusFriendID      string         `json:"plusFriendId"`
+	TemplateCode      TemplateCode   `json:"templateCode"`
+	Content           string         `json:"content"`
+	Buttons           []Button       `json:"buttons,omitempty"`
+	RequestStatusCode string         `json:"requestStatusCode"` // 요청 상태 코드 (A000: 성공)
+	RequestStatusName string         `json:"requestStatusName"` // 요청 상태 (success | fail)
+	RequestStatusDesc string         `json:"requestStatusDesc"` // 요청 상태 내용
+	MessageStatusCode string         `json:"messageStatusCode"` // 발송 결과 코드 (0000: 성공)
+	MessageStatusName string         `json:"messageStatusName"` // 발송 결과 (processing | success | fail)
+	MessageStatusDesc string         `json:"messageStatusDesc"` // 발송 결과 내용
+	CompleteTime      Time           `json:"completeTime"`      // 발송 완료 시간
+	UseSMSFailover    bool           `json:"useSmsFailover"`    // 대체 문자 사용 여부
+}
+
+// Delivered reports whether the message has been delivered to its recipient
+// through KakaoTalk.
+func (mr AlimTalkMessageResult) Delivered() bool {
+	return mr.MessageStatusCode == "0000"
+}
+
+// ListAlimTalkMessagesResponse represents the response sent by the SENS
+// AlimTalk API after a request to list the messages of a send request.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+type ListAlimTalkMessagesResponse struct {
+	RequestID  string                  `json:"requestId"`
+	StatusCode string                  `json:"statusCode"`
+	StatusName string                  `json:"statusName"`
+	Messages   []AlimTalkMessageResult `json:"messages"`
+}
+
+// AlimTalkMessagesEndpoint returns the path of the AlimTalk messages endpoint
+// of the SENS Biz Message service identified by the given service ID.
+func AlimTalkMessagesEndpoint(serviceID string) string {
+	return path.Join(EndpointAlimTalkServices, serviceID, "messages")
+}
+
+// AlimTalkMessageEndpoint returns the path of the endpoint of the AlimTalk
+// message identified by the given message ID in the SENS Biz Message service
+// identified by the given service ID.
+func AlimTalkMessageEndpoint(serviceID, messageID string) string {
+	return path.Join(AlimTalkMessagesEndpoint(serviceID), url.PathEscape(messageID))
+}
+
+// SendAlimTalk sends a request to send AlimTalk messages to the AlimTalk API
+// using the given request parameters. The buttons of the messages are checked
+// with Button.Validate before sending the request, and the messages are
+// checked against their template if the client ValidateTemplates.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+func (kc *KakaoClient) SendAlimTalk(ctx context.Context, req SendAlimTalkRequest) (SendAlimTalkResponse, error) {
+	if kc.ServiceID == "" {
+		return SendAlimTalkResponse{}, ErrMissingServiceID
+	}
+
+	err := checkReserveTime(kc.Clock, req.ReserveTime, req.ReserveTimeZone)
+	if err != nil {
+		return SendAlimTalkResponse{}, err
+	}
+
+	for _, msg := range req.Messages {
+		for _, b := range msg.Buttons {
+			err := b.Validate()
+			if err != nil {
+				return SendAlimTalkResponse{}, err
+			}
+		}
+	}
+
+	if kc.ValidateTemplates {
+		tmpl, err := kc.GetAlimTalkTemplate(ctx, req.PlusFriendID, req.TemplateCode)
+		if err != nil {
+			return SendAlimTalkResponse{}, fmt.Errorf("could not get the template of the request: %w", err)
+		}
+		err = tmpl.Validate(req)
+		if err != nil {
+			return SendAlimTalkResponse{}, err
+		}
+	}
+
+	var resp SendAlimTalkResponse
+	err = kc.api().doJSON(ctx, http.MethodPost, AlimTalkMessagesEndpoint(kc.ServiceID), nil, req, &resp)
+	if err != nil {
+		return SendAlimTalkResponse{}, err
+	}
+
+	if resp.StatusName != "success" {
+		return SendAlimTalkResponse{}, &naverapi.APIError{
+			StatusCode: http.StatusAccepted,
+			Code:       resp.StatusCode,
+			Message:    resp.StatusName,
+			RequestID:  resp.RequestID,
+			Err:        ErrSendAlimTalkFailed,
+		}
+	}
+
+	return resp, nil
+}
+
+// ListAlimTalkMessages lists the AlimTalk messages sent by the send request
+// identified by the given request ID, as returned in SendAlimTalkResponse,
+// with the result of each of them.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+func (kc *KakaoClient) ListAlimTalkMessages(ctx context.Context, requestID string) (ListAlimTalkMessagesResponse, error) {
+	if kc.ServiceID == "" {
+		return ListAlimTalkMessagesResponse{}, ErrMissingServiceID
+	}
+
+	query := url.Values{}
+	query.Set("requestId", requestID)
+
+	var resp ListAlimTalkMessagesResponse
+	err := kc.api().doJSON(ctx, http.MethodGet, AlimTalkMessagesEndpoint(kc.ServiceID), query, nil, &resp)
+	if err != nil {
+		return ListAlimTalkMessagesResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// GetAlimTalkMessageResult returns the result of the AlimTalk message
+// identified by the given message ID, as returned in SendAlimTalkResponse.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+func (kc *KakaoClient) GetAlimTalkMessageResult(ctx context.Context, messageID string) (AlimTalkMessageResult, error) {
+	if kc.ServiceID == "" {
+		return AlimTalkMessageResult{}, ErrMissingServiceID
+	}
+	err := checkID("message ID", messageID)
+	if err != nil {
+		return AlimTalkMessageResult{}, err
+	}
+
+	var resp AlimTalkMessageResult
+	err = kc.api().doJSON(ctx, http.MethodGet, AlimTalkMessageEndpoint(kc.ServiceID, messageID), nil, nil, &resp)
+	if err != nil {
+		return AlimTalkMessageResult{}, err
+	}
+
+	if resp.MessageID == "" {
+		resp.MessageID = messageID
+	}
+
+	return resp, nil
+}
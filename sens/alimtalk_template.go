@@ -0,0 +1,319 @@
+package sens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrTemplateNotFound is returned when looking up an AlimTalk template
+	// which does not exist.
+	ErrTemplateNotFound = errors.New("AlimTalk template not found")
+	// ErrTemplateMismatch is returned when an AlimTalk message does not match
+	// its template.
+	ErrTemplateMismatch = errors.New("the AlimTalk message does not match its template")
+	// ErrTemplateNotApproved is returned when validating a message against a
+	// template which has not been approved yet.
+	ErrTemplateNotApproved = errors.New("the AlimTalk template has not been approved")
+	// ErrTemplateVariable is returned when rendering a template with missing
+	// or unknown variables.
+	ErrTemplateVariable = errors.New("invalid AlimTalk template variables")
+)
+
+// TemplateInspectionStatus is the inspection status of an AlimTalk template.
+type TemplateInspectionStatus string
+
+const (
+	TemplateInspectionRegistered TemplateInspectionStatus = "REG" // 등록
+	TemplateInspectionRequested  TemplateInspectionStatus = "REQ" // 심사 요청
+	TemplateInspectionApproved   TemplateInspectionStatus = "APR" // 승인
+	TemplateInspectionRejected   TemplateInspectionStatus = "REJ" // 반려
+)
+
+// TemplateStatus is the status of an AlimTalk template.
+type TemplateStatus string
+
+const (
+	TemplateStatusReady   TemplateStatus = "READY"   // 대기
+	TemplateStatusActive  TemplateStatus = "ACTIVE"  // 정상
+	TemplateStatusDormant TemplateStatus = "DORMANT" // 휴면
+	TemplateStatusBlocked TemplateStatus = "BLOCKED" // 차단
+)
+
+// TemplateButton is a button defined by an AlimTalk template. Its links may
+// hold template variables.
+type TemplateButton struct {
+	Order int `json:"order"`
+	Button
+}
+
+// TemplateComment is a comment left on an AlimTalk template during its
+// inspection.
+type TemplateComment struct {
+	CommentID  string `json:"commentId"`
+	Content    string `json:"content"`
+	Status     string `json:"status"`
+	CreateTime Time   `json:"createTime"`
+}
+
+// AlimTalkTemplate is an AlimTalk template registered in the SENS console.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+type AlimTalkTemplate struct {
+	CreateTime       Time                     `json:"createTime"`
+	UpdateTime       Time                     `json:"updateTime"`
+	PlusFriendID     string                   `json:"channelId"`                // 카카오톡 채널명
+	TemplateCode     TemplateCode             `json:"templateCode"`             // 템플릿 코드
+	TemplateName     string                   `json:"templateName"`             // 템플릿명
+	Content          string                   `json:"content"`                  // 템플릿 내용(#{변수} 포함)
+	InspectionStatus TemplateInspectionStatus `json:"templateInspectionStatus"` // 검수 상태 (REG | REQ | APR | REJ)
+	Status           TemplateStatus           `json:"templateStatus"`           // 템플릿 상태 (READY | ACTIVE | DORMANT | BLOCKED)
+	Comments         []TemplateComment        `json:"comments,omitempty"`       // 검수 의견
+	Buttons          []TemplateButton         `json:"buttons,omitempty"`        // 버튼 정보
+}
+
+// Approved reports whether the template has passed its inspection and can be
+// used to send messages, i.e. it has not been made dormant or blocked since.
+func (t AlimTalkTemplate) Approved() bool {
+	if t.InspectionStatus != TemplateInspectionApproved {
+		return false
+	}
+	return t.Status != TemplateStatusDormant && t.Status != TemplateStatusBlocked
+}
+
+// templateVariable matches the `#{name}` variables of the AlimTalk templates.
+var templateVariable = regexp.MustCompile(`#\{([^}]*)\}`)
+
+// Variables returns the names of the variables of the template content, in
+// order of first appearance.
+func (t AlimTalkTemplate) Variables() []string {
+	var (
+		names []string
+		seen  = make(map[string]bool)
+	)
+	for _, m := range templateVariable.FindAllStringSubmatch(t.Content, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// Render returns the content of the template with its variables replaced by
+// the given values. It returns an error wrapping ErrTemplateVariable if a
+// variable of the template has no value or if a value matches no variable.
+func (t AlimTalkTemplate) Render(values map[string]string) (string, error) {
+	var missing, unknown []string
+	for _, name := range t.Variables() {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	for name := range values {
+		if !strings.Contains(t.Content, "#{"+name+"}") {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	switch {
+	case len(missing) > 0:
+		return "", fmt.Errorf("%w: template %s is missing %s", ErrTemplateVariable, t.TemplateCode, strings.Join(missing, ", "))
+	case len(unknown) > 0:
+		return "", fmt.Errorf("%w: template %s has no %s", ErrTemplateVariable, t.TemplateCode, strings.Join(unknown, ", "))
+	}
+
+	return templateVariable.ReplaceAllStringFunc(t.Content, func(v string) string {
+		return values[v[2:len(v)-1]]
+	}), nil
+}
+
+// Validate checks that the given request can be sent with the template: the
+// template must be approved, the request must target it and the content and
+// buttons of each message must match the ones of the template once its
+// variables are replaced.
+// It returns an error wrapping ErrTemplateNotApproved or ErrTemplateMismatch
+// otherwise.
+func (t AlimTalkTemplate) Validate(req SendAlimTalkRequest) error {
+	if !t.Approved() {
+		return fmt.Errorf("%w: template %s is %s (%s)", ErrTemplateNotApproved, t.TemplateCode, t.InspectionStatus, t.Status)
+	}
+	if req.TemplateCode != t.TemplateCode {
+		return fmt.Errorf("%w: the request uses template %s instead of %s", ErrTemplateMismatch, req.TemplateCode, t.TemplateCode)
+	}
+	if t.PlusFriendID != "" && req.PlusFriendID != t.PlusFriendID {
+		return fmt.Errorf("%w: the request is sent from %s instead of %s", ErrTemplateMismatch, req.PlusFriendID, t.PlusFriendID)
+	}
+
+	content := templatePattern(t.Content)
+	for i, msg := range req.Messages {
+		if v := templateVariable.FindString(msg.Content); v != "" {
+			return fmt.Errorf("%w: variable %s of message %d to %s has not been replaced", ErrTemplateMismatch, v, i, msg.To)
+		}
+		if !content.MatchString(msg.Content) {
+			return fmt.Errorf("%w: the content of message %d to %s", ErrTemplateMismatch, i, msg.To)
+		}
+		err := t.validateButtons(msg.Buttons)
+		if err != nil {
+			return fmt.Errorf("%w: message %d to %s: %v", ErrTemplateMismatch, i, msg.To, err)
+		}
+	}
+
+	return nil
+}
+
+func (t AlimTalkTemplate) validateButtons(buttons []Button) error {
+	if len(buttons) != len(t.Buttons) {
+		return fmt.Errorf("%d buttons instead of %d", len(buttons), len(t.Buttons))
+	}
+
+	want := append([]TemplateButton(nil), t.Buttons...)
+	sort.SliceStable(want, func(i, j int) bool { return want[i].Order < want[j].Order })
+
+	for i, b := range buttons {
+		w := want[i].Button
+		if b.Type != w.Type || b.Name != w.Name {
+			return fmt.Errorf("button %d is %s %q instead of %s %q", i, b.Type, b.Name, w.Type, w.Name)
+		}
+		for _, link := range []struct{ got, want string }{
+			{got: b.LinkMobile, want: w.LinkMobile},
+			{got: b.LinkPC, want: w.LinkPC},
+			{got: b.SchemeIOS, want: w.SchemeIOS},
+			{got: b.SchemeAndroid, want: w.SchemeAndroid},
+		} {
+			if !templatePattern(link.want).MatchString(link.got) {
+				return fmt.Errorf("the links of button %q do not match %q", b.Name, link.want)
+			}
+		}
+	}
+
+	return nil
+}
+
+// templatePattern returns a regular expression matching the given template
+// text once its variables are replaced.
+func templatePattern(text string) *regexp.Regexp {
+	var (
+		b    strings.Builder
+		last int
+	)
+	b.WriteString(`(?s)^`)
+	for _, loc := range templateVariable.FindAllStringIndex(text, -1) {
+		b.WriteString(regexp.QuoteMeta(text[last:loc[0]]))
+		b.WriteString(`.*?`)
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(text[last:]))
+	b.WriteString(`$`)
+
+	return regexp.MustCompile(b.String())
+}
+
+// KakaoChannel is a KakaoTalk channel registered in a SENS Biz Message
+// service.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+type KakaoChannel struct {
+	CreateTime    Time   `json:"createTime"`
+	UpdateTime    Time   `json:"updateTime"`
+	ServiceID     string `json:"serviceId"`
+	PlusFriendID  string `json:"channelId"`     // 카카오톡 채널명(@ 포함)
+	ChannelName   string `json:"channelName"`   // 채널 이름
+	ChannelStatus string `json:"channelStatus"` // 채널 상태
+}
+
+// ListAlimTalkTemplatesRequest holds the filters of the AlimTalk templates
+// listing.
+type ListAlimTalkTemplatesRequest struct {
+	PlusFriendID string       // 카카오톡 채널명 - 필수
+	TemplateCode TemplateCode // 템플릿 코드 - 선택
+	TemplateName string       // 템플릿명 - 선택
+}
+
+// AlimTalkTemplatesEndpoint returns the path of the AlimTalk templates
+// endpoint of the SENS Biz Message service identified by the given service
+// ID.
+func AlimTalkTemplatesEndpoint(serviceID string) string {
+	return path.Join(EndpointAlimTalkServices, serviceID, "templates")
+}
+
+// KakaoChannelsEndpoint returns the path of the KakaoTalk channels endpoint
+// of the SENS Biz Message service identified by the given service ID.
+func KakaoChannelsEndpoint(serviceID string) string {
+	return path.Join(EndpointAlimTalkServices, serviceID, "channels")
+}
+
+// ListAlimTalkTemplates lists the AlimTalk templates of a KakaoTalk channel
+// matching the given filters, with their inspection status and buttons.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+func (kc *KakaoClient) ListAlimTalkTemplates(ctx context.Context, req ListAlimTalkTemplatesRequest) ([]AlimTalkTemplate, error) {
+	if kc.ServiceID == "" {
+		return nil, ErrMissingServiceID
+	}
+
+	query := url.Values{}
+	query.Set("channelId", req.PlusFriendID)
+	if req.TemplateCode != "" {
+		query.Set("templateCode", string(req.TemplateCode))
+	}
+	if req.TemplateName != "" {
+		query.Set("templateName", req.TemplateName)
+	}
+
+	var resp []AlimTalkTemplate
+	err := kc.api().doJSON(ctx, http.MethodGet, AlimTalkTemplatesEndpoint(kc.ServiceID), query, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetAlimTalkTemplate returns the AlimTalk template of the given KakaoTalk
+// channel identified by the given template code.
+// It returns ErrTemplateNotFound if there is no such template.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+func (kc *KakaoClient) GetAlimTalkTemplate(ctx context.Context, plusFriendID string, code TemplateCode) (AlimTalkTemplate, error) {
+	templates, err := kc.ListAlimTalkTemplates(ctx, ListAlimTalkTemplatesRequest{
+		PlusFriendID: plusFriendID,
+		TemplateCode: code,
+	})
+	if err != nil {
+		return AlimTalkTemplate{}, err
+	}
+
+	for _, t := range templates {
+		if t.TemplateCode == code {
+			return t, nil
+		}
+	}
+	return AlimTalkTemplate{}, fmt.Errorf("%w: %s", ErrTemplateNotFound, code)
+}
+
+// ListKakaoChannels lists the KakaoTalk channels registered in the SENS Biz
+// Message service.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-alimtalkv2
+func (kc *KakaoClient) ListKakaoChannels(ctx context.Context) ([]KakaoChannel, error) {
+	if kc.ServiceID == "" {
+		return nil, ErrMissingServiceID
+	}
+
+	var resp []KakaoChannel
+	err := kc.api().doJSON(ctx, http.MethodGet, KakaoChannelsEndpoint(kc.ServiceID), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,274 @@
+package sens_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/connectfit-team/naverapi/internal/testhelper"
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+const validListAlimTalkTemplatesResponse = `
+	[
+		{
+			"createTime": "2023-03-02 10:15:00",
+			"updateTime": "2023-03-03 09:00:00",
+			"channelId": "@test_channel",
+			"templateCode": "ORDER_RECEIVED",
+			"templateName": "주문 접수",
+			"content": "#{name}님, 주문(#{orderId})이 접수되었습니다.",
+			"templateInspectionStatus": "APR",
+			"templateStatus": "ACTIVE",
+			"buttons": [
+				{
+					"order": 2,
+					"type": "WL",
+					"name": "주문 상세",
+					"linkMobile": "https://m.example.com/orders/#{orderId}"
+				},
+				{
+					"order": 1,
+					"type": "DS",
+					"name": "배송 조회"
+				}
+			]
+		}
+	]
+`
+
+func orderReceivedTemplate(t *testing.T) sens.AlimTalkTemplate {
+	t.Helper()
+
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.AlimTalkTemplatesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, validListAlimTalkTemplatesResponse)
+	})
+
+	tmpl, err := client.GetAlimTalkTemplate(context.Background(), "@test_channel", "ORDER_RECEIVED")
+	if err != nil {
+		t.Fatalf("could not get the test template: %v", err)
+	}
+	return tmpl
+}
+
+func TestKakaoClient_ListAlimTalkTemplates(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.AlimTalkTemplatesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Iam-Access-Key", "test-access-key")
+
+		want := "channelId=%40test_channel&templateCode=ORDER_RECEIVED"
+		if r.URL.RawQuery != want {
+			t.Errorf("Expected query %q but got %q", want, r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, validListAlimTalkTemplatesResponse)
+	})
+
+	got, err := client.ListAlimTalkTemplates(context.Background(), sens.ListAlimTalkTemplatesRequest{
+		PlusFriendID: "@test_channel",
+		TemplateCode: "ORDER_RECEIVED",
+	})
+	if err != nil {
+		t.Fatalf("List AlimTalk templates request was given a valid request but failed: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 template but got %d", len(got))
+	}
+	if !got[0].Approved() || got[0].Status != sens.TemplateStatusActive || len(got[0].Buttons) != 2 {
+		t.Errorf("Unexpected template: %+v", got[0])
+	}
+}
+
+func TestKakaoClient_GetAlimTalkTemplate_ShouldFailIfNotFound(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.AlimTalkTemplatesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	_, err := client.GetAlimTalkTemplate(context.Background(), "@test_channel", "UNKNOWN")
+	if !errors.Is(err, sens.ErrTemplateNotFound) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrTemplateNotFound, err)
+	}
+}
+
+func TestKakaoClient_ListKakaoChannels(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.KakaoChannelsEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, `[{"serviceId":"ncp:kkobizmsg:kr:1234567:test_channel","channelId":"@test_channel","channelName":"테스트","channelStatus":"ACTIVE"}]`)
+	})
+
+	got, err := client.ListKakaoChannels(context.Background())
+	if err != nil {
+		t.Fatalf("List Kakao channels request was given a valid request but failed: %v", err)
+	}
+
+	want := []sens.KakaoChannel{
+		{
+			ServiceID:     testKakaoServiceID,
+			PlusFriendID:  "@test_channel",
+			ChannelName:   "테스트",
+			ChannelStatus: "ACTIVE",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Mismatch between the expected and the returned channels (-want +got):\n%s", diff)
+	}
+}
+
+func TestAlimTalkTemplate_Render(t *testing.T) {
+	tmpl := orderReceivedTemplate(t)
+
+	got, err := tmpl.Render(map[string]string{"name": "홍길동", "orderId": "1234"})
+	if err != nil {
+		t.Fatalf("Render was given all the variables but failed: %v", err)
+	}
+	if want := "홍길동님, 주문(1234)이 접수되었습니다."; got != want {
+		t.Errorf("Expected %q but got %q", want, got)
+	}
+
+	_, err = tmpl.Render(map[string]string{"name": "홍길동"})
+	if !errors.Is(err, sens.ErrTemplateVariable) {
+		t.Errorf("Expected error %v for a missing variable but got: %v", sens.ErrTemplateVariable, err)
+	}
+
+	_, err = tmpl.Render(map[string]string{"name": "홍길동", "orderId": "1234", "coupon": "X"})
+	if !errors.Is(err, sens.ErrTemplateVariable) {
+		t.Errorf("Expected error %v for an unknown variable but got: %v", sens.ErrTemplateVariable, err)
+	}
+}
+
+func TestAlimTalkTemplate_Validate(t *testing.T) {
+	tmpl := orderReceivedTemplate(t)
+
+	validMessage := func() sens.AlimTalkMessage {
+		return sens.AlimTalkMessage{
+			To:      "01012345678",
+			Content: "홍길동님, 주문(1234)이 접수되었습니다.",
+			Buttons: []sens.Button{
+				sens.DeliveryTrackingButton("배송 조회"),
+				sens.WebLinkButton("주문 상세", "https://m.example.com/orders/1234", ""),
+			},
+		}
+	}
+	request := func(msg sens.AlimTalkMessage) sens.SendAlimTalkRequest {
+		return sens.SendAlimTalkRequest{
+			PlusFriendID: "@test_channel",
+			TemplateCode: "ORDER_RECEIVED",
+			Messages:     []sens.AlimTalkMessage{msg},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		tmpl    func() sens.AlimTalkTemplate
+		req     func() sens.SendAlimTalkRequest
+		wantErr error
+	}{
+		{
+			name: "valid message",
+			req:  func() sens.SendAlimTalkRequest { return request(validMessage()) },
+		},
+		{
+			name: "template not approved",
+			tmpl: func() sens.AlimTalkTemplate {
+				t := tmpl
+				t.InspectionStatus = sens.TemplateInspectionRequested
+				return t
+			},
+			req:     func() sens.SendAlimTalkRequest { return request(validMessage()) },
+			wantErr: sens.ErrTemplateNotApproved,
+		},
+		{
+			name: "template blocked",
+			tmpl: func() sens.AlimTalkTemplate {
+				t := tmpl
+				t.Status = sens.TemplateStatusBlocked
+				return t
+			},
+			req:     func() sens.SendAlimTalkRequest { return request(validMessage()) },
+			wantErr: sens.ErrTemplateNotApproved,
+		},
+		{
+			name: "template dormant",
+			tmpl: func() sens.AlimTalkTemplate {
+				t := tmpl
+				t.Status = sens.TemplateStatusDormant
+				return t
+			},
+			req:     func() sens.SendAlimTalkRequest { return request(validMessage()) },
+			wantErr: sens.ErrTemplateNotApproved,
+		},
+		{
+			name: "other template",
+			req: func() sens.SendAlimTalkRequest {
+				req := request(validMessage())
+				req.TemplateCode = "ORDER_SHIPPED"
+				return req
+			},
+			wantErr: sens.ErrTemplateMismatch,
+		},
+		{
+			name: "content mismatch",
+			req: func() sens.SendAlimTalkRequest {
+				msg := validMessage()
+				msg.Content = "홍길동님, 주문이 접수되었습니다."
+				return request(msg)
+			},
+			wantErr: sens.ErrTemplateMismatch,
+		},
+		{
+			name: "variable left in the content",
+			req: func() sens.SendAlimTalkRequest {
+				msg := validMessage()
+				msg.Content = "#{name}님, 주문(1234)이 접수되었습니다."
+				return request(msg)
+			},
+			wantErr: sens.ErrTemplateMismatch,
+		},
+		{
+			name: "missing button",
+			req: func() sens.SendAlimTalkRequest {
+				msg := validMessage()
+				msg.Buttons = msg.Buttons[:1]
+				return request(msg)
+			},
+			wantErr: sens.ErrTemplateMismatch,
+		},
+		{
+			name: "button link mismatch",
+			req: func() sens.SendAlimTalkRequest {
+				msg := validMessage()
+				msg.Buttons[1].LinkMobile = "https://m.example.com/cart"
+				return request(msg)
+			},
+			wantErr: sens.ErrTemplateMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := tmpl
+			if tt.tmpl != nil {
+				tm = tt.tmpl()
+			}
+			err := tm.Validate(tt.req())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v but got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
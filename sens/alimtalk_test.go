@@ -0,0 +1,299 @@
+package sens_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/connectfit-team/naverapi/internal/testhelper"
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+const validSendAlimTalkResponse = `
+	{
+		"requestId": "test-request-id",
+		"requestTime": "2023-03-02T10:15:00.000",
+		"statusCode": "202",
+		"statusName": "success",
+		"messages": [
+			{
+				"messageId": "test-message-id",
+				"countryCode": "82",
+				"to": "01012345678",
+				"content": "주문이 접수되었습니다.",
+				"requestStatusCode": "A000",
+				"requestStatusName": "success",
+				"requestStatusDesc": "성공",
+				"useSmsFailover": true
+			}
+		]
+	}
+`
+
+const validGetAlimTalkMessageResponse = `
+	{
+		"requestId": "test-request-id",
+		"messageId": "test-message-id",
+		"requestTime": "2023-03-02 10:15:00",
+		"countryCode": "82",
+		"to": "01012345678",
+		"plusFriendId": "@test_channel",
+		"templateCode": "ORDER_RECEIVED",
+		"content": "주문이 접수되었습니다.",
+		"requestStatusCode": "A000",
+		"requestStatusName": "success",
+		"requestStatusDesc": "성공",
+		"messageStatusCode": "0000",
+		"messageStatusName": "success",
+		"messageStatusDesc": "정상 발송",
+		"completeTime": "2023-03-02 10:15:03",
+		"useSmsFailover": true
+	}
+`
+
+func TestKakaoClient_SendAlimTalk(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	req := sens.SendAlimTalkRequest{
+		PlusFriendID: "@test_channel",
+		TemplateCode: "ORDER_RECEIVED",
+		Messages: []sens.AlimTalkMessage{
+			{
+				To:      "01012345678",
+				Content: "주문이 접수되었습니다.",
+				Buttons: []sens.Button{
+					sens.DeliveryTrackingButton("배송 조회"),
+					sens.WebLinkButton("주문 상세", "https://m.example.com/orders/1", ""),
+				},
+				UseSMSFailover: true,
+				FailoverConfig: &sens.FailoverConfig{
+					Type: sens.SMSTypeSMS,
+					From: "0212345678",
+				},
+			},
+		},
+	}
+
+	mux.HandleFunc(sens.AlimTalkMessagesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodPost)
+
+		testhelper.TestRequestHeader(t, r, "Content-Type", "application/json")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Timestamp", "856915200000")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Iam-Access-Key", "test-access-key")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "TDcOYJZaio2GSqv4ls1BOnhmur0kL4+O8z/jD9lug+A=")
+
+		var got sens.SendAlimTalkRequest
+		err := json.NewDecoder(r.Body).Decode(&got)
+		if err != nil {
+			t.Errorf("could not decode the request body: %v", err)
+		}
+		if diff := cmp.Diff(req, got); diff != "" {
+			t.Errorf("Mismatch between the expected and the sent request (-want +got):\n%s", diff)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, validSendAlimTalkResponse)
+	})
+
+	got, err := client.SendAlimTalk(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Send AlimTalk request was given a valid request but failed: %v", err)
+	}
+
+	want := sens.SendAlimTalkResponse{
+		RequestID:   "test-request-id",
+		RequestTime: "2023-03-02T10:15:00.000",
+		StatusCode:  "202",
+		StatusName:  "success",
+		Messages: []sens.KakaoMessageRequest{
+			{
+				MessageID:         "test-message-id",
+				CountryCode:       sens.CountryCodeKorea,
+				To:                "01012345678",
+				Content:           "주문이 접수되었습니다.",
+				RequestStatusCode: "A000",
+				RequestStatusName: "success",
+				RequestStatusDesc: "성공",
+				UseSMSFailover:    true,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Mismatch between the expected and the returned response (-want +got):\n%s", diff)
+	}
+}
+
+func TestKakaoClient_SendAlimTalk_ShouldFailIfStatusIsNotSuccess(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.AlimTalkMessagesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusCode":"202","statusName":"fail"}`)
+	})
+
+	_, err := client.SendAlimTalk(context.Background(), sens.SendAlimTalkRequest{})
+	if !errors.Is(err, sens.ErrSendAlimTalkFailed) {
+		t.Fatalf("Expected error %v but got: %v", sens.ErrSendAlimTalkFailed, err)
+	}
+	var apiErr *naverapi.APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != "test-request-id" {
+		t.Errorf("Expected an API error with the request ID but got: %v", err)
+	}
+}
+
+func TestKakaoClient_SendAlimTalk_ShouldValidateButtons(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.AlimTalkMessagesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("A request with invalid buttons shouldn't be sent")
+	})
+
+	tests := []struct {
+		name   string
+		button sens.Button
+	}{
+		{name: "web link without mobile link", button: sens.WebLinkButton("주문 상세", "", "https://example.com")},
+		{name: "app link without android scheme", button: sens.AppLinkButton("앱 열기", "example://orders", "")},
+		{name: "missing name", button: sens.DeliveryTrackingButton("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := sens.SendAlimTalkRequest{
+				Messages: []sens.AlimTalkMessage{{To: "01012345678", Buttons: []sens.Button{tt.button}}},
+			}
+			_, err := client.SendAlimTalk(context.Background(), req)
+			if !errors.Is(err, sens.ErrInvalidButton) {
+				t.Errorf("Expected error %v but got: %v", sens.ErrInvalidButton, err)
+			}
+		})
+	}
+}
+
+func TestKakaoClient_ListAlimTalkMessages(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.AlimTalkMessagesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "7g68+7JdzoyWCHOY3AUZB0fvV3jZdFR9uA/s3vbb3E4=")
+
+		fmt.Fprintf(w, `{"requestId":"test-request-id","statusCode":"202","statusName":"success","messages":[%s]}`, validGetAlimTalkMessageResponse)
+	})
+
+	got, err := client.ListAlimTalkMessages(context.Background(), "test-request-id")
+	if err != nil {
+		t.Fatalf("List AlimTalk messages request was given a valid request but failed: %v", err)
+	}
+	if len(got.Messages) != 1 || !got.Messages[0].Delivered() {
+		t.Errorf("Expected a single delivered message but got: %+v", got.Messages)
+	}
+}
+
+func TestKakaoClient_GetAlimTalkMessageResult(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.AlimTalkMessageEndpoint(testKakaoServiceID, "test-message-id"), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, validGetAlimTalkMessageResponse)
+	})
+
+	got, err := client.GetAlimTalkMessageResult(context.Background(), "test-message-id")
+	if err != nil {
+		t.Fatalf("Get AlimTalk message result request was given a valid request but failed: %v", err)
+	}
+
+	want := sens.AlimTalkMessageResult{
+		RequestID:         "test-request-id",
+		MessageID:         "test-message-id",
+		RequestTime:       sens.Time{Time: time.Date(2023, 3, 2, 10, 15, 0, 0, sens.KST)},
+		CountryCode:       sens.CountryCodeKorea,
+		To:                "01012345678",
+		PlusFriendID:      "@test_channel",
+		TemplateCode:      "ORDER_RECEIVED",
+		Content:           "주문이 접수되었습니다.",
+		RequestStatusCode: "A000",
+		RequestStatusName: "success",
+		RequestStatusDesc: "성공",
+		MessageStatusCode: "0000",
+		MessageStatusName: "success",
+		MessageStatusDesc: "정상 발송",
+		CompleteTime:      sens.Time{Time: time.Date(2023, 3, 2, 10, 15, 3, 0, sens.KST)},
+		UseSMSFailover:    true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Mismatch between the expected and the returned result (-want +got):\n%s", diff)
+	}
+}
+
+func TestKakaoClient_SendAlimTalk_ShouldValidateTemplatesIfEnabled(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+	client.ValidateTemplates = true
+
+	mux.HandleFunc(sens.AlimTalkTemplatesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, validListAlimTalkTemplatesResponse)
+	})
+	sent := 0
+	mux.HandleFunc(sens.AlimTalkMessagesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		fmt.Fprint(w, validSendAlimTalkResponse)
+	})
+
+	req := sens.SendAlimTalkRequest{
+		PlusFriendID: "@test_channel",
+		TemplateCode: "ORDER_RECEIVED",
+		Messages: []sens.AlimTalkMessage{{
+			To:      "01012345678",
+			Content: "홍길동님, 주문이 접수되었습니다.",
+			Buttons: []sens.Button{
+				sens.DeliveryTrackingButton("배송 조회"),
+				sens.WebLinkButton("주문 상세", "https://m.example.com/orders/1234", ""),
+			},
+		}},
+	}
+	_, err := client.SendAlimTalk(context.Background(), req)
+	if !errors.Is(err, sens.ErrTemplateMismatch) {
+		t.Fatalf("Expected error %v but got: %v", sens.ErrTemplateMismatch, err)
+	}
+	if sent != 0 {
+		t.Fatalf("A request which does not match its template shouldn't be sent")
+	}
+
+	req.Messages[0].Content = "홍길동님, 주문(1234)이 접수되었습니다."
+	_, err = client.SendAlimTalk(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Send AlimTalk request was given a request matching its template but failed: %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("Expected the request to be sent once but it was sent %d times", sent)
+	}
+}
+
+func TestKakaoClient_GetAlimTalkMessageResult_ShouldFailIfNoMessageID(t *testing.T) {
+	client, _, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	_, err := client.GetAlimTalkMessageResult(context.Background(), "")
+	if !errors.Is(err, sens.ErrMissingID) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMissingID, err)
+	}
+}
+
+func TestAlimTalkMessageEndpoint_ShouldEscapeTheMessageID(t *testing.T) {
+	got := sens.AlimTalkMessageEndpoint(testKakaoServiceID, "test/message")
+	want := sens.AlimTalkMessagesEndpoint(testKakaoServiceID) + "/test%2Fmessage"
+	if got != want {
+		t.Errorf("Expected the path %q but got %q", want, got)
+	}
+}
@@ -0,0 +1,172 @@
+package sens
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/connectfit-team/naverapi/internal/httputil"
+)
+
+// ClientConfig holds the settings shared by the clients of the SENS APIs. It is
+// embedded in Client, KakaoClient and PushClient.
+type ClientConfig struct {
+	// HTTPClient is the HTTP client used internally to perform the requests.
+	HTTPClient *http.Client
+	// BaseURL is the base URL which prefix every request's URL path.
+	// e.g. https://sens.apigw.ntruss.com
+	BaseURL *url.URL
+	// Credentials provides the access key and secret key (from portal or sub
+	// account) used to sign each request.
+	// Replace it to use another provider, e.g. naverapi.DefaultCredentials().
+	Credentials naverapi.CredentialsProvider
+	// ServiceID is the ID of the SENS project the requests are sent to, e.g.
+	// ncp:sms:kr:123456789012:my_project for a SMS service,
+	// ncp:kkobizmsg:kr:1234567:my_project for a Biz Message service or
+	// ncp:push:kr:1234567:my_app for a Push service.
+	ServiceID string
+	// RetryPolicy describes how the failed requests are retried.
+	// Set it to naverapi.NoRetry to send each request only once.
+	RetryPolicy naverapi.RetryPolicy
+	// Limiter, if set, limits the rate at which the requests are sent.
+	// Share the same limiter between the clients using the same credentials.
+	Limiter naverapi.Limiter
+	// Clock provides the current time used to fill the `x-ncp-apigw-timestamp`
+	// header of each request to the API.
+	// It has been made public mainly for testing purpose to avoid polluting the
+	// public API with extra parameters or options.
+	Clock Clock
+}
+
+// newClientConfig returns the settings of a client authenticating with the
+// given access key and secret key to the SENS service identified by the given
+// service ID. It uses the http.DefaultClient unless httpClient is not nil.
+func newClientConfig(accessKey, secretKey, serviceID string, httpClient *http.Client) (ClientConfig, error) {
+	baseURL, err := url.Parse(SENSDefaultBaseURL)
+	if err != nil {
+		return ClientConfig{}, fmt.Errorf("malformed base URL %q: %w", baseURL, err)
+	}
+
+	cfg := ClientConfig{
+		HTTPClient:  http.DefaultClient,
+		BaseURL:     baseURL,
+		Credentials: naverapi.NewStaticCredentials(accessKey, secretKey),
+		ServiceID:   serviceID,
+		RetryPolicy: naverapi.DefaultRetryPolicy,
+		Clock:       &realClock{},
+	}
+
+	if httpClient != nil {
+		cfg.HTTPClient = httpClient
+	}
+
+	return cfg, nil
+}
+
+// UseSite points the client to the SENS API gateway of the given Naver Cloud
+// Platform site. BaseURL can still be set afterwards to use another host.
+func (cfg *ClientConfig) UseSite(site naverapi.Site) {
+	cfg.BaseURL = site.BaseURL(SENSGatewayHost)
+}
+
+// api returns the settings used to perform the requests.
+func (cfg *ClientConfig) api() apiClient {
+	return apiClient{
+		httpClient:  cfg.HTTPClient,
+		baseURL:     cfg.BaseURL,
+		credentials: signingCredentials(cfg.Credentials),
+		retryPolicy: cfg.RetryPolicy,
+		limiter:     cfg.Limiter,
+		clock:       cfg.Clock,
+	}
+}
+
+// apiClient holds the settings shared by the clients of the SENS APIs, which
+// are all signed the same way.
+type apiClient struct {
+	httpClient  *http.Client
+	baseURL     *url.URL
+	credentials httputil.CredentialsFunc
+	retryPolicy naverapi.RetryPolicy
+	limiter     naverapi.Limiter
+	clock       Clock
+}
+
+// checkID returns an error wrapping ErrMissingID if the given identifier,
+// described by name, is empty.
+func checkID(name, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: %s", ErrMissingID, name)
+	}
+	return nil
+}
+
+// signingCredentials adapts the given provider to resolve the keys used to
+// sign the requests.
+func signingCredentials(provider naverapi.CredentialsProvider) httputil.CredentialsFunc {
+	return func(ctx context.Context) (accessKey, secretKey string, err error) {
+		if provider == nil {
+			return "", "", naverapi.ErrNoCredentials
+		}
+		creds, err := provider.Credentials(ctx)
+		return creds.AccessKey, creds.SecretKey, err
+	}
+}
+
+// do performs the given request, signing it with the credentials once allowed
+// by the limiter and retrying it according to the retry policy.
+func (c apiClient) do(req *http.Request) (*http.Response, error) {
+	signer := httputil.Signer{
+		Credentials: c.credentials,
+		Clock:       c.clock,
+	}
+	client := httputil.NewSignedClient(c.httpClient, signer)
+	client.Transport = naverapi.LimitTransport(c.limiter, client.Transport)
+	return c.retryPolicy.Client(client).Do(req)
+}
+
+// doJSON performs a request to the given path of the API, sending the given
+// request body as JSON if not nil, and decodes the JSON response body into
+// respBody if not nil.
+// It returns a *naverapi.APIError if the response status code is not a 2xx
+// one.
+func (c apiClient) doJSON(ctx context.Context, method, endpointPath string, query url.Values, reqBody, respBody any) error {
+	endpoint := c.baseURL.JoinPath(endpointPath)
+	endpoint.RawQuery = query.Encode()
+
+	var (
+		httpReq *http.Request
+		err     error
+	)
+	if reqBody != nil {
+		httpReq, err = httputil.NewJSONBodyRequest(ctx, method, endpoint.String(), reqBody)
+	} else {
+		httpReq, err = http.NewRequestWithContext(ctx, method, endpoint.String(), nil)
+	}
+	if err != nil {
+		return fmt.Errorf("could not build the request: %w", err)
+	}
+
+	resp, err := c.do(httpReq)
+	if err != nil {
+		return fmt.Errorf("could not perform the HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return naverapi.NewAPIError(resp)
+	}
+
+	if respBody == nil {
+		return nil
+	}
+	err = json.NewDecoder(resp.Body).Decode(respBody)
+	if err != nil {
+		return fmt.Errorf("could not decode response body: %w", err)
+	}
+
+	return nil
+}
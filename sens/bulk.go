@@ -0,0 +1,203 @@
+package sens
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	// MaxMessagesPerRequest is the maximum number of messages the SENS SMS
+	// API accepts in a single send request.
+	MaxMessagesPerRequest = 100
+	// DefaultBulkConcurrency is the default number of send requests SendBulk
+	// performs concurrently.
+	DefaultBulkConcurrency = 4
+)
+
+// BulkOptions describes how SendBulk splits and sends a request.
+type BulkOptions struct {
+	// ChunkSize is the number of messages sent per request, at most
+	// MaxMessagesPerRequest which is also the default.
+	ChunkSize int
+	// Concurrency is the maximum number of requests sent concurrently.
+	// Defaults to DefaultBulkConcurrency.
+	Concurrency int
+}
+
+// BulkChunkResult is the result of one of the requests sent by SendBulk.
+type BulkChunkResult struct {
+	// Messages are the messages sent by the request.
+	Messages []Message
+	// Response is the response to the request, if it succeeded.
+	Response SendSMSResponse
+	// Err is the error returned by SendSMS, if any.
+	Err error
+}
+
+// BulkRecipientResult is the result of a message sent by SendBulk.
+type BulkRecipientResult struct {
+	// To is the recipient of the message.
+	To string
+	// Chunk is the index of the request the message has been sent with.
+	Chunk int
+	// RequestID is the ID of the request the message has been sent with,
+	// empty if it failed.
+	RequestID string
+	// Err is the error of the request the message has been sent with, if
+	// any.
+	Err error
+}
+
+// BulkResult is the result of SendBulk.
+type BulkResult struct {
+	// Chunks are the results of the requests, in order.
+	Chunks []BulkChunkResult
+	// Recipients are the results of the messages, in the order of the
+	// request.
+	Recipients []BulkRecipientResult
+	// OptedOut are the messages dropped because their recipient opted out,
+	// when the client filters them.
+	OptedOut []Message
+}
+
+// RequestIDs returns the IDs of the requests which succeeded, in order.
+func (br BulkResult) RequestIDs() []string {
+	var ids []string
+	for _, c := range br.Chunks {
+		if c.Err == nil {
+			ids = append(ids, c.Response.RequestID)
+		}
+	}
+	return ids
+}
+
+// Failed returns the results of the messages which could not be sent.
+func (br BulkResult) Failed() []BulkRecipientResult {
+	var failed []BulkRecipientResult
+	for _, r := range br.Recipients {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// BulkError is returned by SendBulk when some of its requests failed.
+type BulkError struct {
+	// Failed is the number of messages which could not be sent.
+	Failed int
+	// Total is the number of messages of the bulk request.
+	Total int
+	// Err is the error of the first failed request.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("could not send %d of the %d messages: %v", e.Failed, e.Total, e.Err)
+}
+
+// Unwrap returns the error of the first failed request.
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
+
+// SendBulk sends the messages of the given request with as many SendSMS
+// requests of at most opts.ChunkSize messages as needed, opts.Concurrency of
+// them at most being sent at the same time. The client Limiter, if any, is
+// shared by all the requests.
+//
+// The returned result reports the outcome of each request and message. If
+// some of the requests failed, the others are still sent and a *BulkError is
+// returned along with the result.
+//
+// If the client has an OptOuts registry, the opt-outs are looked up once and
+// the messages of advertisements sent to numbers which opted out are dropped
+// before splitting the request. They are reported in the result, and
+// ErrAllRecipientsOptedOut is returned if no message remains.
+func (ss *Client) SendBulk(ctx context.Context, req SendSMSRequest, opts BulkOptions) (BulkResult, error) {
+	if ss.ServiceID == "" {
+		return BulkResult{}, ErrMissingServiceID
+	}
+
+	var optedOut []Message
+	if ss.OptOuts != nil {
+		var err error
+		req, optedOut, err = ss.FilterOptedOut(ctx, req)
+		if err != nil {
+			return BulkResult{}, fmt.Errorf("could not filter the opted out recipients: %w", err)
+		}
+		if len(req.Messages) == 0 && len(optedOut) > 0 {
+			return BulkResult{OptedOut: optedOut}, ErrAllRecipientsOptedOut
+		}
+	}
+
+	size := opts.ChunkSize
+	if size <= 0 || size > MaxMessagesPerRequest {
+		size = MaxMessagesPerRequest
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	var chunks []BulkChunkResult
+	for start := 0; start < len(req.Messages); start += size {
+		end := start + size
+		if end > len(req.Messages) {
+			end = len(req.Messages)
+		}
+		chunks = append(chunks, BulkChunkResult{Messages: req.Messages[start:end]})
+	}
+
+	var (
+		wg      sync.WaitGroup
+		indexes = make(chan int)
+	)
+	for w := 0; w < concurrency && w < len(chunks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					chunks[i].Err = err
+					continue
+				}
+				r := req
+				r.Messages = chunks[i].Messages
+				chunks[i].Response, chunks[i].Err = ss.sendSMS(ctx, r)
+			}
+		}()
+	}
+	for i := range chunks {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	result := BulkResult{Chunks: chunks, OptedOut: optedOut}
+	var bulkErr *BulkError
+	for i, c := range chunks {
+		for _, msg := range c.Messages {
+			result.Recipients = append(result.Recipients, BulkRecipientResult{
+				To:        msg.To,
+				Chunk:     i,
+				RequestID: c.Response.RequestID,
+				Err:       c.Err,
+			})
+		}
+		if c.Err == nil {
+			continue
+		}
+		if bulkErr == nil {
+			bulkErr = &BulkError{Total: len(req.Messages), Err: c.Err}
+		}
+		bulkErr.Failed += len(c.Messages)
+	}
+
+	if bulkErr != nil {
+		return result, bulkErr
+	}
+	return result, nil
+}
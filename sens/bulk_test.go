@@ -0,0 +1,160 @@
+package sens_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/connectfit-team/naverapi/sens"
+)
+
+func bulkRequest(n int) sens.SendSMSRequest {
+	req := sens.SendSMSRequest{
+		Type:        sens.SMSTypeSMS,
+		ContentType: sens.ContentTypeSMS,
+		From:        "0212345678",
+		Content:     "test-content",
+	}
+	for i := 0; i < n; i++ {
+		req.Messages = append(req.Messages, sens.Message{To: fmt.Sprintf("010%08d", i)})
+	}
+	return req
+}
+
+func TestSENSClient_SendBulk(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	limiter := &countingLimiter{}
+	client.Limiter = limiter
+
+	var (
+		mu                  sync.Mutex
+		inFlight, maxFlight int
+		sizes               = make(map[string]int)
+	)
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxFlight {
+			maxFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		var req sens.SendSMSRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			t.Errorf("could not decode the request body: %v", err)
+		}
+		requestID := "request-" + req.Messages[0].To
+
+		mu.Lock()
+		inFlight--
+		sizes[requestID] = len(req.Messages)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"requestId":%q,"statusName":"success"}`, requestID)
+	})
+
+	got, err := client.SendBulk(context.Background(), bulkRequest(250), sens.BulkOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Send bulk request was given a valid request but failed: %v", err)
+	}
+
+	wantIDs := []string{"request-01000000000", "request-01000000100", "request-01000000200"}
+	if gotIDs := got.RequestIDs(); strings.Join(gotIDs, ",") != strings.Join(wantIDs, ",") {
+		t.Errorf("Expected request IDs %v but got %v", wantIDs, gotIDs)
+	}
+	if sizes[wantIDs[0]] != 100 || sizes[wantIDs[1]] != 100 || sizes[wantIDs[2]] != 50 {
+		t.Errorf("Expected chunks of 100, 100 and 50 messages but got %v", sizes)
+	}
+	if maxFlight > 2 {
+		t.Errorf("Expected at most 2 concurrent requests but got %d", maxFlight)
+	}
+	if limiter.waits != 3 {
+		t.Errorf("Expected the limiter to be waited for 3 times but got %d", limiter.waits)
+	}
+
+	if len(got.Recipients) != 250 {
+		t.Fatalf("Expected 250 recipient results but got %d", len(got.Recipients))
+	}
+	last := got.Recipients[249]
+	if last.To != "01000000249" || last.Chunk != 2 || last.RequestID != wantIDs[2] || last.Err != nil {
+		t.Errorf("Unexpected result for the last recipient: %+v", last)
+	}
+}
+
+func TestSENSClient_SendBulk_ShouldReportPartialFailures(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.RetryPolicy = naverapi.NoRetry
+
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		var req sens.SendSMSRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			t.Errorf("could not decode the request body: %v", err)
+		}
+		if req.Messages[0].To == "01000000010" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"errorCode":"400","message":"Bad Request"}`)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"requestId":"request-%s","statusName":"success"}`, req.Messages[0].To)
+	})
+
+	got, err := client.SendBulk(context.Background(), bulkRequest(25), sens.BulkOptions{ChunkSize: 10})
+
+	var bulkErr *sens.BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("Expected a bulk error but got: %v", err)
+	}
+	if bulkErr.Failed != 10 || bulkErr.Total != 25 {
+		t.Errorf("Expected 10 of 25 messages to fail but got %d of %d", bulkErr.Failed, bulkErr.Total)
+	}
+	var apiErr *naverapi.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected the bulk error to wrap the API error but got: %v", err)
+	}
+
+	failed := got.Failed()
+	if len(failed) != 10 || failed[0].To != "01000000010" || failed[0].Chunk != 1 {
+		t.Errorf("Unexpected failed recipients: %+v", failed)
+	}
+	if ids := got.RequestIDs(); len(ids) != 2 {
+		t.Errorf("Expected 2 successful requests but got %v", ids)
+	}
+}
+
+func TestSENSClient_SendBulk_ShouldStopWhenContextIsCanceled(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("No request should be sent once the context is canceled")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	got, err := client.SendBulk(ctx, bulkRequest(150), sens.BulkOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected error %v but got: %v", context.Canceled, err)
+	}
+	if len(got.Failed()) != 150 {
+		t.Errorf("Expected all the 150 messages to fail but got %d", len(got.Failed()))
+	}
+}
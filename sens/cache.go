@@ -0,0 +1,71 @@
+package sens
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// numberCache caches a set of numbers listed from the API, such as the
+// calling numbers or the opted out numbers of a project.
+// The numbers are listed by a single caller at a time, without holding the
+// lock, the others waiting for the listing to end.
+type numberCache struct {
+	mu        sync.Mutex
+	numbers   map[string]bool
+	fetchedAt time.Time
+	// refreshing, if not nil, is closed once the pending listing of the
+	// numbers is over.
+	refreshing chan struct{}
+}
+
+// invalidate makes the cache list the numbers again on its next use.
+func (c *numberCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.numbers = nil
+}
+
+// get returns the cached numbers, listing them with list if they are not
+// cached or have expired at the given time. The returned set must not be
+// modified.
+func (c *numberCache) get(ctx context.Context, ttl time.Duration, now time.Time, list func(ctx context.Context) (map[string]bool, error)) (map[string]bool, error) {
+	for {
+		c.mu.Lock()
+		if c.numbers != nil && now.Before(c.fetchedAt.Add(ttl)) {
+			numbers := c.numbers
+			c.mu.Unlock()
+			return numbers, nil
+		}
+
+		if refreshing := c.refreshing; refreshing != nil {
+			c.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-refreshing:
+				// Check the numbers again, or list them if the listing
+				// failed.
+				continue
+			}
+		}
+
+		refreshing := make(chan struct{})
+		c.refreshing = refreshing
+		c.mu.Unlock()
+
+		numbers, err := list(ctx)
+
+		c.mu.Lock()
+		c.refreshing = nil
+		close(refreshing)
+		if err == nil {
+			c.numbers = numbers
+			c.fetchedAt = now
+		}
+		c.mu.Unlock()
+
+		return numbers, err
+	}
+}
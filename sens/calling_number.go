@@ -0,0 +1,138 @@
+package sens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+)
+
+// DefaultCallingNumbersTTL is how long the calling numbers are cached by a
+// CallingNumberRegistry without TTL.
+const DefaultCallingNumbersTTL = 10 * time.Minute
+
+// ErrUnregisteredCallingNumber is wrapped by the
+// *UnregisteredCallingNumberError returned when a message is sent from a
+// number which is not registered as a calling number of the project.
+var ErrUnregisteredCallingNumber = errors.New("the calling number is not registered")
+
+// UnregisteredCallingNumberError is returned when a message is sent from a
+// number which is not registered as a calling number of the project.
+type UnregisteredCallingNumberError struct {
+	// Number is the unregistered number.
+	Number string
+}
+
+// Error implements the error interface.
+func (e *UnregisteredCallingNumberError) Error() string {
+	return fmt.Sprintf("%v: %q", ErrUnregisteredCallingNumber, e.Number)
+}
+
+// Unwrap returns ErrUnregisteredCallingNumber.
+func (e *UnregisteredCallingNumberError) Unwrap() error {
+	return ErrUnregisteredCallingNumber
+}
+
+// CallingNumber is a number registered in the SENS console to send the
+// messages from.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+type CallingNumber struct {
+	Number string `json:"callingNumber"` // 발신 번호
+}
+
+// CallingNumbersEndpoint returns the path of the calling numbers endpoint of
+// the SENS SMS service identified by the given service ID.
+func CallingNumbersEndpoint(serviceID string) string {
+	return path.Join(EndpointSMSServices, serviceID, "calling-numbers")
+}
+
+// ListCallingNumbers lists the calling numbers registered in the SENS SMS
+// service.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+func (ss *Client) ListCallingNumbers(ctx context.Context) ([]CallingNumber, error) {
+	if ss.ServiceID == "" {
+		return nil, ErrMissingServiceID
+	}
+
+	var resp []CallingNumber
+	err := ss.api().doJSON(ctx, http.MethodGet, CallingNumbersEndpoint(ss.ServiceID), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CallingNumberRegistry caches the calling numbers of a SENS SMS service.
+// It is safe for concurrent use.
+type CallingNumberRegistry struct {
+	// TTL is how long the calling numbers are cached before being listed
+	// again. Defaults to DefaultCallingNumbersTTL.
+	TTL time.Duration
+
+	cache numberCache
+}
+
+// Invalidate makes the registry list the calling numbers again on its next
+// use, e.g. after registering a new one in the SENS console.
+func (r *CallingNumberRegistry) Invalidate() {
+	r.cache.invalidate()
+}
+
+// registered reports whether the given number is one of the calling numbers
+// of the given client, listing them if they are not cached or have expired
+// at the given time.
+func (r *CallingNumberRegistry) registered(ctx context.Context, ss *Client, number string, now time.Time) (bool, error) {
+	ttl := r.TTL
+	if ttl <= 0 {
+		ttl = DefaultCallingNumbersTTL
+	}
+
+	numbers, err := r.cache.get(ctx, ttl, now, func(ctx context.Context) (map[string]bool, error) {
+		callingNumbers, err := ss.ListCallingNumbers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		numbers := make(map[string]bool, len(callingNumbers))
+		for _, n := range callingNumbers {
+			numbers[callingNumberKey(n.Number)] = true
+		}
+		return numbers, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return numbers[callingNumberKey(number)], nil
+}
+
+// CheckCallingNumber checks that the given number is registered as a calling
+// number of the SENS SMS service, using the client CallingNumbers registry if
+// any. Numbers are compared once normalized with NormalizeSenderNumber.
+// It returns an *UnregisteredCallingNumberError if it is not.
+func (ss *Client) CheckCallingNumber(ctx context.Context, number string) error {
+	registry := ss.CallingNumbers
+	if registry == nil {
+		registry = &CallingNumberRegistry{}
+	}
+
+	ok, err := registry.registered(ctx, ss, number, now(ss.Clock))
+	if err != nil {
+		return fmt.Errorf("could not list the calling numbers: %w", err)
+	}
+	if !ok {
+		return &UnregisteredCallingNumberError{Number: number}
+	}
+	return nil
+}
+
+func callingNumberKey(number string) string {
+	n, err := NormalizeSenderNumber(number)
+	if err != nil {
+		return number
+	}
+	return n
+}
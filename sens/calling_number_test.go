@@ -0,0 +1,180 @@
+package sens_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi/internal/testhelper"
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSENSClient_ListCallingNumbers(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.CallingNumbersEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, `[{"callingNumber":"0212345678"},{"callingNumber":"01012345678"}]`)
+	})
+
+	got, err := client.ListCallingNumbers(context.Background())
+	if err != nil {
+		t.Fatalf("List calling numbers request was given a valid request but failed: %v", err)
+	}
+
+	want := []sens.CallingNumber{{Number: "0212345678"}, {Number: "01012345678"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Mismatch between the expected and the returned calling numbers (-want +got):\n%s", diff)
+	}
+}
+
+func TestSENSClient_CheckCallingNumber(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.CallingNumbers = &sens.CallingNumberRegistry{TTL: time.Hour}
+
+	listings := 0
+	mux.HandleFunc(sens.CallingNumbersEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		listings++
+		fmt.Fprint(w, `[{"callingNumber":"0212345678"}]`)
+	})
+
+	err := client.CheckCallingNumber(context.Background(), "02-1234-5678")
+	if err != nil {
+		t.Fatalf("Expected the number to be registered but got: %v", err)
+	}
+
+	err = client.CheckCallingNumber(context.Background(), "0287654321")
+	var unregisteredErr *sens.UnregisteredCallingNumberError
+	if !errors.As(err, &unregisteredErr) || unregisteredErr.Number != "0287654321" {
+		t.Fatalf("Expected an *UnregisteredCallingNumberError for %q but got: %v", "0287654321", err)
+	}
+	if !errors.Is(err, sens.ErrUnregisteredCallingNumber) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrUnregisteredCallingNumber, err)
+	}
+	if listings != 1 {
+		t.Errorf("Expected the calling numbers to be listed once but they were listed %d times", listings)
+	}
+
+	client.Clock = &fixedTimeClock{fixedTime: time.Date(1997, 2, 26, 1, 0, 0, 0, time.UTC)}
+	_ = client.CheckCallingNumber(context.Background(), "0212345678")
+	if listings != 2 {
+		t.Errorf("Expected the calling numbers to be listed again once expired but they were listed %d times", listings)
+	}
+
+	client.CallingNumbers.Invalidate()
+	_ = client.CheckCallingNumber(context.Background(), "0212345678")
+	if listings != 3 {
+		t.Errorf("Expected the calling numbers to be listed again once invalidated but they were listed %d times", listings)
+	}
+}
+
+func TestSENSClient_CheckCallingNumber_ShouldListTheNumbersOnceForConcurrentChecks(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.CallingNumbers = &sens.CallingNumberRegistry{TTL: time.Hour}
+
+	var (
+		mu       sync.Mutex
+		listings int
+		listing  = make(chan struct{})
+		release  = make(chan struct{})
+	)
+	mux.HandleFunc(sens.CallingNumbersEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		listings++
+		if listings == 1 {
+			close(listing)
+		}
+		mu.Unlock()
+
+		<-release
+		fmt.Fprint(w, `[{"callingNumber":"0212345678"}]`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.CheckCallingNumber(context.Background(), "0212345678"); err != nil {
+				t.Errorf("Expected the number to be registered but got: %v", err)
+			}
+		}()
+	}
+
+	<-listing
+	invalidated := make(chan struct{})
+	go func() {
+		client.CallingNumbers.Invalidate()
+		close(invalidated)
+	}()
+	select {
+	case <-invalidated:
+	case <-time.After(time.Second):
+		t.Error("Expected the registry not to be locked while listing the calling numbers")
+	}
+
+	close(release)
+	wg.Wait()
+
+	if listings != 1 {
+		t.Errorf("Expected the calling numbers to be listed once but they were listed %d times", listings)
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldRejectUnregisteredCallingNumbers(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.CallingNumbers = &sens.CallingNumberRegistry{}
+
+	mux.HandleFunc(sens.CallingNumbersEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"callingNumber":"0212345678"}]`)
+	})
+	sent := 0
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusName":"success"}`)
+	})
+
+	req := bulkRequest(1)
+	_, err := client.SendSMS(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Send SMS request was given a registered calling number but failed: %v", err)
+	}
+
+	req.From = "0287654321"
+	_, err = client.SendSMS(context.Background(), req)
+	if !errors.Is(err, sens.ErrUnregisteredCallingNumber) {
+		t.Fatalf("Expected error %v but got: %v", sens.ErrUnregisteredCallingNumber, err)
+	}
+	if sent != 1 {
+		t.Errorf("Expected the request from the unregistered number not to be sent but %d requests were sent", sent)
+	}
+}
+
+func TestSENSClient_WithServiceID_ShouldNotShareTheCallingNumbers(t *testing.T) {
+	client, _, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.CallingNumbers = &sens.CallingNumberRegistry{TTL: time.Hour}
+
+	other := client.WithServiceID("ncp:sms:kr:123456789012:other_project")
+	if other.CallingNumbers == client.CallingNumbers {
+		t.Fatal("Expected the copy to have its own calling numbers registry")
+	}
+	if other.CallingNumbers.TTL != time.Hour {
+		t.Errorf("Expected the copy registry TTL to be %v but got %v", time.Hour, other.CallingNumbers.TTL)
+	}
+}
@@ -6,22 +6,30 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strconv"
+	"path"
 
+	"github.com/connectfit-team/naverapi"
 	"github.com/connectfit-team/naverapi/internal/httputil"
 )
 
 const (
 	SENSDefaultBaseURL = "https://sens.apigw.ntruss.com"
+	SENSGatewayHost    = "sens"
 
-	EndpointSMSAPI   = "/sms/v2"
-	EndpointMessages = EndpointSMSAPI + "/services/ncp:sms:kr:273248310490:sms_service/messages"
+	EndpointSMSAPI      = "/sms/v2"
+	EndpointSMSServices = EndpointSMSAPI + "/services"
 )
 
 var (
-	// ErrSendSMSFailed is returned when the `statusName` in the response after
-	// requesting to send a SMS is not "success".
+	// ErrMissingServiceID is returned when a request is made by a client
+	// which has no SENS service ID configured.
+	ErrMissingServiceID = errors.New("the SENS service ID is missing")
+	// ErrMissingID is returned when a request is made for a resource, e.g. a
+	// message or a reservation, whose identifier is empty.
+	ErrMissingID = errors.New("the resource identifier is missing")
+	// ErrSendSMSFailed is wrapped in the *naverapi.APIError returned when the
+	// `statusName` in the response after requesting to send a SMS is not
+	// "success".
 	ErrSendSMSFailed = errors.New(`the send SMS request's response did not return status "success"`)
 )
 
@@ -49,60 +57,72 @@ const (
 // Client is a REST client providing methods to interact with
 // Naver Cloud Platform SENS(Simple & Easy Notification Service).
 type Client struct {
-	// HTTPClient is the HTTP client used internally to perform the requests.
-	HTTPClient *http.Client
-	// BaseURL is the base URL which prefix every request's URL path.
-	// e.g. https://sens.apigw.ntruss.com
-	BaseURL *url.URL
-	// AccessKey is the access key (from portal or sub account)
-	AccessKey string
-	// SecretKey is the secret key (from portal or sub account)
-	SecretKey string
-	// Clock provides the current time used to fill the `x-ncp-apigw-timestamp`
-	// header of each request to the API.
-	// It has been made public mainly for testing purpose to avoid polluting the
-	// public API with extra parameters or options.
-	Clock Clock
+	ClientConfig
+	// AdPolicy, if set, makes the advertisements (ContentTypeAD) sent by
+	// SendSMS comply with the Korean regulations, see AdPolicy.Apply.
+	AdPolicy *AdPolicy
+	// OptOuts, if set, caches the numbers which opted out of the
+	// advertisements so that SendSMS and SendBulk drop them from the
+	// recipients of the advertisements, see FilterOptedOut.
+	OptOuts *OptOutRegistry
+	// CallingNumbers, if set, caches the calling numbers registered in the
+	// SENS SMS service so that SendSMS and SendBulk check the From of the
+	// requests before sending them, see CheckCallingNumber.
+	CallingNumbers *CallingNumberRegistry
 }
 
-// NewClient returns a new Naver Cloud Platform SMS API client given a
-// base URL, an access key and a secret key to authenticate to the API.
+// NewClient returns a new Naver Cloud Platform SMS API client given an access
+// key and a secret key to authenticate to the API and the ID of the SENS SMS
+// service (project) to send the messages from.
 // It uses the http.DefaultClient unless you provide your own.
-func NewClient(accessKey, secretKey string, httpClient *http.Client) (*Client, error) {
-	baseURL, err := url.Parse(SENSDefaultBaseURL)
+func NewClient(accessKey, secretKey, serviceID string, httpClient *http.Client) (*Client, error) {
+	cfg, err := newClientConfig(accessKey, secretKey, serviceID, httpClient)
 	if err != nil {
-		return nil, fmt.Errorf("malformed base URL %q: %w", baseURL, err)
+		return nil, err
 	}
 
-	svc := &Client{
-		HTTPClient: http.DefaultClient,
-		BaseURL:    baseURL,
-		AccessKey:  accessKey,
-		SecretKey:  secretKey,
-		Clock:      &realClock{},
-	}
+	return &Client{ClientConfig: cfg}, nil
+}
 
-	if httpClient != nil {
-		svc.HTTPClient = httpClient
+// WithServiceID returns a copy of the client sending its requests to the
+// SENS SMS service identified by the given service ID.
+// The copy shares the HTTP client, credentials and clock of the original one,
+// which makes it cheap to drive several SENS projects from the same process.
+// It gets its own CallingNumbers and OptOuts registries, with the same TTL,
+// if the original one has them.
+func (ss *Client) WithServiceID(serviceID string) *Client {
+	c := *ss
+	c.ServiceID = serviceID
+	if ss.CallingNumbers != nil {
+		c.CallingNumbers = &CallingNumberRegistry{TTL: ss.CallingNumbers.TTL}
 	}
+	if ss.OptOuts != nil {
+		c.OptOuts = &OptOutRegistry{TTL: ss.OptOuts.TTL}
+	}
+	return &c
+}
 
-	return svc, nil
+// MessagesEndpoint returns the path of the messages endpoint of the SENS SMS
+// service identified by the given service ID.
+func MessagesEndpoint(serviceID string) string {
+	return path.Join(EndpointSMSServices, serviceID, "messages")
 }
 
 // SendSMSRequest represents a the REST request to send a SMS.
 //
 // See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
 type SendSMSRequest struct {
-	Type            SMSType        `json:"type"`                      // SMS 타입 (SMS | LMS | MMS) - 필수
+	Type            SMSType        `json:"type"`                      // SMS 타입 (SMS | LMS | MMS | AUTO) - 필수
 	ContentType     SMSContentType `json:"contentType"`               // 메세지 타입 (COMM(일반) | AD(광고)) - 필수
 	CountryCode     SMSCountryCode `json:"countryCode,omitempty"`     // 국가 코드(default 82) - 선택
 	From            string         `json:"from"`                      // 문자 발송 번호 - 필수
 	Subject         string         `json:"subject,omitempty"`         // 문자 제목(LMS, MMS 만 사용) - 선택
 	Content         string         `json:"content"`                   // 기본 문자 내용(EUC-KR 인코딩, 지원 외 이모지 발송 실패) - 필수
 	Messages        []Message      `json:"messages"`                  // 문자 정보 - 필수
-	ReserveTime     string         `json:"reserveTime,omitempty"`     // 예약 시간("yyyy-MM-dd HH:mm") - 선택
+	ReserveTime     string         `json:"reserveTime,omitempty"`     // 예약 시간("yyyy-MM-dd HH:mm", ReserveAt 으로 설정 가능) - 선택
 	ReserveTimeZone string         `json:"reserveTimeZone,omitempty"` // 예약 시간 타임존("Asia/Seoul") - 선택
 	ScheduleCode    string         `json:"scheduleCode,omitempty"`
+	Files           []File         `json:"files,omitempty"` // MMS 이미지 파일(UploadFile 로 업로드) - 선택
 }
 
 type Message struct {
@@ -120,33 +140,100 @@ type SendSMSResponse struct {
 	RequestTime string `json:"requestTime"`
 	StatusCode  string `json:"statusCode"`
 	StatusName  string `json:"statusName"`
+	// OptedOut are the messages dropped because their recipient opted out,
+	// when the client filters them.
+	OptedOut []Message `json:"-"`
 }
 
 // SendSMS sends a request to send a SMS to the SMS API using the given request
 // parameters.
+// If the request type is SMSTypeAuto, the type is chosen with SelectSMSType
+// and the request fails before being sent if a message is too long or can't be
+// encoded in EUC-KR.
+// A reserved request fails before being sent if its ReserveTime is malformed,
+// already passed or further than MaxReserveAhead according to the client
+// Clock.
+// If the client has an AdPolicy, advertisements are made compliant with it at
+// the time given by the client Clock before being sent, or fail with an
+// *AdComplianceError.
+// If the client has an OptOuts registry, the messages of advertisements sent
+// to numbers which opted out are dropped and reported in the response, even
+// if the request fails. The request fails with ErrAllRecipientsOptedOut if no
+// message remains.
+// If the client has a CallingNumbers registry, the request fails with an
+// *UnregisteredCallingNumberError before being sent if its From is not a
+// registered calling number.
 //
 // See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
 func (ss *Client) SendSMS(ctx context.Context, req SendSMSRequest) (SendSMSResponse, error) {
-	endpoint := ss.BaseURL.JoinPath(EndpointMessages).String()
-	httpReq, err := httputil.NewJSONBodyRequest(ctx, http.MethodPost, endpoint, req)
+	if ss.ServiceID == "" {
+		return SendSMSResponse{}, ErrMissingServiceID
+	}
+
+	if ss.OptOuts == nil {
+		return ss.sendSMS(ctx, req)
+	}
+
+	req, optedOut, err := ss.FilterOptedOut(ctx, req)
 	if err != nil {
-		return SendSMSResponse{}, fmt.Errorf("could not build the send message request: %w", err)
+		return SendSMSResponse{}, fmt.Errorf("could not filter the opted out recipients: %w", err)
+	}
+	if len(req.Messages) == 0 {
+		return SendSMSResponse{OptedOut: optedOut}, ErrAllRecipientsOptedOut
 	}
 
-	timestamp := strconv.FormatInt(ss.Clock.Now().UnixMilli(), 10)
-	err = httputil.SetNCloudRequestHeaders(httpReq, EndpointMessages, timestamp, ss.AccessKey, ss.SecretKey)
+	resp, err := ss.sendSMS(ctx, req)
 	if err != nil {
-		return SendSMSResponse{}, fmt.Errorf("failed to set the HTTP header of the request: %w", err)
+		return SendSMSResponse{OptedOut: optedOut}, err
+	}
+	resp.OptedOut = optedOut
+
+	return resp, nil
+}
+
+// sendSMS sends the given request without filtering its recipients.
+func (ss *Client) sendSMS(ctx context.Context, req SendSMSRequest) (SendSMSResponse, error) {
+	err := checkReserveTime(ss.Clock, req.ReserveTime, req.ReserveTimeZone)
+	if err != nil {
+		return SendSMSResponse{}, err
+	}
+
+	if ss.AdPolicy != nil {
+		req, err = ss.AdPolicy.Apply(req, now(ss.Clock))
+		if err != nil {
+			return SendSMSResponse{}, err
+		}
+	}
+
+	if req.Type == SMSTypeAuto {
+		typ, err := SelectSMSType(req)
+		if err != nil {
+			return SendSMSResponse{}, err
+		}
+		req.Type = typ
+	}
+
+	if ss.CallingNumbers != nil {
+		err = ss.CheckCallingNumber(ctx, req.From)
+		if err != nil {
+			return SendSMSResponse{}, err
+		}
+	}
+
+	endpoint := ss.BaseURL.JoinPath(MessagesEndpoint(ss.ServiceID)).String()
+	httpReq, err := httputil.NewJSONBodyRequest(ctx, http.MethodPost, endpoint, req)
+	if err != nil {
+		return SendSMSResponse{}, fmt.Errorf("could not build the send message request: %w", err)
 	}
 
-	resp, err := ss.HTTPClient.Do(httpReq)
+	resp, err := ss.api().do(httpReq)
 	if err != nil {
 		return SendSMSResponse{}, fmt.Errorf("could not perform the HTTP request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusAccepted {
-		return SendSMSResponse{}, fmt.Errorf("request failed with code %d: %s", resp.StatusCode, resp.Status)
+		return SendSMSResponse{}, naverapi.NewAPIError(resp)
 	}
 
 	var responseBody SendSMSResponse
@@ -156,7 +243,13 @@ func (ss *Client) SendSMS(ctx context.Context, req SendSMSRequest) (SendSMSRespo
 	}
 
 	if responseBody.StatusName != "success" {
-		return SendSMSResponse{}, ErrSendSMSFailed
+		return SendSMSResponse{}, &naverapi.APIError{
+			StatusCode: resp.StatusCode,
+			Code:       responseBody.StatusCode,
+			Message:    responseBody.StatusName,
+			RequestID:  responseBody.RequestID,
+			Err:        ErrSendSMSFailed,
+		}
 	}
 
 	return responseBody, nil
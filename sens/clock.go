@@ -10,3 +10,11 @@ type Clock interface {
 type realClock struct{}
 
 func (rc realClock) Now() time.Time { return time.Now() }
+
+// now returns the current time according to the given clock, if any.
+func now(clock Clock) time.Time {
+	if clock == nil {
+		return time.Now()
+	}
+	return clock.Now()
+}
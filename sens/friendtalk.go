@@ -0,0 +1,392 @@
+package sens
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/connectfit-team/naverapi"
+)
+
+const (
+	EndpointFriendTalkAPI      = "/friendtalk/v2"
+	EndpointFriendTalkServices = EndpointFriendTalkAPI + "/services"
+)
+
+const (
+	// MaxFriendTalkImageSize is the maximum size in bytes of a FriendTalk
+	// image.
+	MaxFriendTalkImageSize = 500 * 1024
+	// MinFriendTalkImageWidth is the minimum width in pixels of a FriendTalk
+	// image.
+	MinFriendTalkImageWidth = 500
+	// MinWideFriendTalkImageWidth and MinWideFriendTalkImageHeight are the
+	// minimum dimensions in pixels of a wide FriendTalk image.
+	MinWideFriendTalkImageWidth  = 800
+	MinWideFriendTalkImageHeight = 600
+)
+
+var (
+	// ErrSendFriendTalkFailed is wrapped in the *naverapi.APIError returned
+	// when the `statusName` in the response after requesting to send
+	// FriendTalk messages is not "success".
+	ErrSendFriendTalkFailed = errors.New(`the send FriendTalk request's response did not return status "success"`)
+	// ErrInvalidFriendTalkImageFormat is returned when a FriendTalk image is
+	// neither a JPEG nor a PNG.
+	ErrInvalidFriendTalkImageFormat = errors.New("FriendTalk images must be JPEG or PNG files")
+	// ErrFriendTalkImageTooLarge is returned when a FriendTalk image exceeds
+	// MaxFriendTalkImageSize.
+	ErrFriendTalkImageTooLarge = errors.New("the FriendTalk image is too large")
+	// ErrInvalidFriendTalkImageDimensions is returned when the dimensions or
+	// the aspect ratio of a FriendTalk image are not allowed, see
+	// ValidateFriendTalkImage.
+	ErrInvalidFriendTalkImageDimensions = errors.New("invalid FriendTalk image dimensions")
+)
+
+// FriendTalkImage references an image uploaded with UploadFriendTalkImage in
+// a FriendTalk message.
+type FriendTalkImage struct {
+	ImageID   string `json:"imageId"`             // 이미지 아이디 - 필수
+	ImageLink string `json:"imageLink,omitempty"` // 이미지 클릭 시 이동할 링크 - 선택
+}
+
+// FriendTalkMessage is a FriendTalk message sent to a single recipient.
+type FriendTalkMessage struct {
+	CountryCode    SMSCountryCode   `json:"countryCode,omitempty"`    // 국가 코드(default 82) - 선택
+	To             string           `json:"to"`                       // 수신자 번호 - 필수
+	Content        string           `json:"content"`                  // 메시지 내용 - 필수
+	IsAdult        bool             `json:"isAdult,omitempty"`        // 성인 전용 메시지 여부 - 선택
+	Image          *FriendTalkImage `json:"image,omitempty"`          // 이미지 정보 - 선택
+	Buttons        []Button         `json:"buttons,omitempty"`        // 버튼 정보 - 선택
+	UseSMSFailover bool             `json:"useSmsFailover,omitempty"` // 실패 시 대체 문자 발송 여부 - 선택
+	FailoverConfig *FailoverConfig  `json:"failoverConfig,omitempty"` // 대체 문자 정보 - 선택
+}
+
+// SendFriendTalkRequest represents the REST request to send FriendTalk
+// messages.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-friendtalkv2
+type SendFriendTalkRequest struct {
+	PlusFriendID    string              `json:"plusFriendId"`              // 카카오톡 채널명(@ 포함) - 필수
+	ContentType     SMSContentType      `json:"contentType,omitempty"`     // 메세지 타입 (COMM(일반) | AD(광고)) - 선택
+	Messages        []FriendTalkMessage `json:"messages"`                  // 메시지 정보 - 필수
+	ReserveTime     string              `json:"reserveTime,omitempty"`     // 예약 시간("yyyy-MM-dd HH:mm", ReserveAt 으로 설정 가능) - 선택
+	ReserveTimeZone string              `json:"reserveTimezone,omitempty"` // 예약 시간 타임존("Asia/Seoul") - 선택
+	ScheduleCode    string              `json:"scheduleCode,omitempty"`
+}
+
+// SendFriendTalkResponse represents the response sent by the SENS FriendTalk
+// API after a request to send FriendTalk messages.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-friendtalkv2
+type SendFriendTalkResponse struct {
+	RequestID   string                `json:"requestId"`
+	RequestTime string                `json:"requestTime"`
+	StatusCode  string                `json:"statusCode"`
+	StatusName  string                `json:"statusName"`
+	Messages    []KakaoMessageRequest `json:"messages"`
+}
+
+// FriendTalkMessageResult represents the result of a FriendTalk message sent
+// to a single recipient.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-friendtalkv2
+type FriendTalkMessageResult struct {
+	RequestID         string           `json:"requestId,omitempty"` // 메시지 조회 시에만 반환
+	MessageID         string           `json:"messageId"`
+	RequestTime       Time             `json:"requestTime"`
+	CountryCode       SMSCountryCode   `json:"countryCode"`
+	To                string           `json:"to"`
+	PlusFriendID      string           `json:"plusFriendId"`
+	Content           string           `json:"content"`
+	Image             *FriendTalkImage `json:"image,omitempty"`
+	Buttons           []Button         `json:"buttons,omitempty"`
+	RequestStatusCode string           `json:"requestStatusCode"` // 요청 상태 코드 (A000: 성공)
+	RequestStatusName string           `json:"requestStatusName"` // 요청 상태 (success | fail)
+	RequestStatusDesc string           `json:"requestStatusDesc"` // 요청 상태 내용
+	MessageStatusCode string           `json:"messageStatusCode"` // 발송 결과 코드 (0000: 성공)
+	MessageStatusName string           `json:"messageStatusName"` // 발송 결과 (processing | success | fail)
+	MessageStatusDesc string           `json:"messageStatusDesc"` // 발송 결과 내용
+	CompleteTime      Time             `json:"completeTime"`      // 발송 완료 시간
+	UseSMSFailover    bool             `json:"useSmsFailover"`    // 대체 문자 사용 여부
+}
+
+// Delivered reports whether the message has been delivered to its recipient
+// through KakaoTalk.
+func (mr FriendTalkMessageResult) Delivered() bool {
+	return mr.MessageStatusCode == "0000"
+}
+
+// ListFriendTalkMessagesResponse represents the response sent by the SENS
+// FriendTalk API after a request to list the messages of a send request.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-friendtalkv2
+type ListFriendTalkMessagesResponse struct {
+	RequestID  string                    `json:"requestId"`
+	StatusCode string                    `json:"statusCode"`
+	StatusName string                    `json:"statusName"`
+	Messages   []FriendTalkMessageResult `json:"messages"`
+}
+
+// UploadFriendTalkImageResponse represents the response sent by the SENS
+// FriendTalk API after a request to upload an image.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-friendtalkv2
+type UploadFriendTalkImageResponse struct {
+	ImageID    string `json:"imageId"`
+	ImageName  string `json:"imageName"`
+	ImageURL   string `json:"imageUrl"`
+	IsWide     bool   `json:"isWide"`
+	CreateTime Time   `json:"createTime"`
+}
+
+// FriendTalkMessagesEndpoint returns the path of the FriendTalk messages
+// endpoint of the SENS Biz Message service identified by the given service ID.
+func FriendTalkMessagesEndpoint(serviceID string) string {
+	return path.Join(EndpointFriendTalkServices, serviceID, "messages")
+}
+
+// FriendTalkMessageEndpoint returns the path of the endpoint of the FriendTalk
+// message identified by the given message ID in the SENS Biz Message service
+// identified by the given service ID.
+func FriendTalkMessageEndpoint(serviceID, messageID string) string {
+	return path.Join(FriendTalkMessagesEndpoint(serviceID), url.PathEscape(messageID))
+}
+
+// FriendTalkImagesEndpoint returns the path of the FriendTalk images endpoint
+// of the SENS Biz Message service identified by the given service ID.
+func FriendTalkImagesEndpoint(serviceID string) string {
+	return path.Join(EndpointFriendTalkServices, serviceID, "images")
+}
+
+// SendFriendTalk sends a request to send FriendTalk messages to the
+// FriendTalk API using the given request parameters. The buttons of the
+// messages are checked with Button.Validate before sending the request.
+//
+// Advertisements must be sent with the ContentTypeAD content type. Unlike
+// SendSMS, the client only passes it to the API: no AdPolicy is applied and
+// no opt-out list is checked, the recipients of FriendTalk messages opting out
+// by blocking the KakaoTalk channel.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-friendtalkv2
+func (kc *KakaoClient) SendFriendTalk(ctx context.Context, req SendFriendTalkRequest) (SendFriendTalkResponse, error) {
+	if kc.ServiceID == "" {
+		return SendFriendTalkResponse{}, ErrMissingServiceID
+	}
+
+	err := checkReserveTime(kc.Clock, req.ReserveTime, req.ReserveTimeZone)
+	if err != nil {
+		return SendFriendTalkResponse{}, err
+	}
+
+	for _, msg := range req.Messages {
+		for _, b := range msg.Buttons {
+			err := b.Validate()
+			if err != nil {
+				return SendFriendTalkResponse{}, err
+			}
+		}
+	}
+
+	var resp SendFriendTalkResponse
+	err = kc.api().doJSON(ctx, http.MethodPost, FriendTalkMessagesEndpoint(kc.ServiceID), nil, req, &resp)
+	if err != nil {
+		return SendFriendTalkResponse{}, err
+	}
+
+	if resp.StatusName != "success" {
+		return SendFriendTalkResponse{}, &naverapi.APIError{
+			StatusCode: http.StatusAccepted,
+			Code:       resp.StatusCode,
+			Message:    resp.StatusName,
+			RequestID:  resp.RequestID,
+			Err:        ErrSendFriendTalkFailed,
+		}
+	}
+
+	return resp, nil
+}
+
+// ListFriendTalkMessages lists the FriendTalk messages sent by the send
+// request identified by the given request ID, as returned in
+// SendFriendTalkResponse, with the result of each of them.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-friendtalkv2
+func (kc *KakaoClient) ListFriendTalkMessages(ctx context.Context, requestID string) (ListFriendTalkMessagesResponse, error) {
+	if kc.ServiceID == "" {
+		return ListFriendTalkMessagesResponse{}, ErrMissingServiceID
+	}
+
+	query := url.Values{}
+	query.Set("requestId", requestID)
+
+	var resp ListFriendTalkMessagesResponse
+	err := kc.api().doJSON(ctx, http.MethodGet, FriendTalkMessagesEndpoint(kc.ServiceID), query, nil, &resp)
+	if err != nil {
+		return ListFriendTalkMessagesResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// GetFriendTalkMessageResult returns the result of the FriendTalk message
+// identified by the given message ID, as returned in SendFriendTalkResponse.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-friendtalkv2
+func (kc *KakaoClient) GetFriendTalkMessageResult(ctx context.Context, messageID string) (FriendTalkMessageResult, error) {
+	if kc.ServiceID == "" {
+		return FriendTalkMessageResult{}, ErrMissingServiceID
+	}
+	err := checkID("message ID", messageID)
+	if err != nil {
+		return FriendTalkMessageResult{}, err
+	}
+
+	var resp FriendTalkMessageResult
+	err = kc.api().doJSON(ctx, http.MethodGet, FriendTalkMessageEndpoint(kc.ServiceID, messageID), nil, nil, &resp)
+	if err != nil {
+		return FriendTalkMessageResult{}, err
+	}
+
+	if resp.MessageID == "" {
+		resp.MessageID = messageID
+	}
+
+	return resp, nil
+}
+
+// ValidateFriendTalkImage checks that the given file can be sent as a
+// FriendTalk image: a JPEG or PNG file of at most MaxFriendTalkImageSize
+// bytes. A regular image must be at least MinFriendTalkImageWidth pixels wide
+// and its aspect ratio between 2:1 and 3:4, while a wide one must be at least
+// MinWideFriendTalkImageWidth x MinWideFriendTalkImageHeight pixels and its
+// aspect ratio between 2:1 and 4:3.
+func ValidateFriendTalkImage(name string, content []byte, wide bool) error {
+	var decodeConfig func(r io.Reader) (image.Config, error)
+	switch ext := strings.ToLower(filepath.Ext(name)); ext {
+	case ".jpg", ".jpeg":
+		decodeConfig = jpeg.DecodeConfig
+	case ".png":
+		decodeConfig = png.DecodeConfig
+	default:
+		return fmt.Errorf("%w: unsupported extension %q", ErrInvalidFriendTalkImageFormat, ext)
+	}
+
+	if len(content) > MaxFriendTalkImageSize {
+		return fmt.Errorf("%w: %d bytes, at most %d bytes are allowed", ErrFriendTalkImageTooLarge, len(content), MaxFriendTalkImageSize)
+	}
+
+	cfg, err := decodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFriendTalkImageFormat, err)
+	}
+	if wide {
+		return validateWideFriendTalkImage(cfg)
+	}
+	if cfg.Width < MinFriendTalkImageWidth {
+		return fmt.Errorf("%w: %dx%d, at least %d pixels wide are required", ErrInvalidFriendTalkImageDimensions, cfg.Width, cfg.Height, MinFriendTalkImageWidth)
+	}
+	// The width must be between 3/4 and 2 times the height.
+	if 4*cfg.Width < 3*cfg.Height || cfg.Width > 2*cfg.Height {
+		return fmt.Errorf("%w: %dx%d, the aspect ratio must be between 2:1 and 3:4", ErrInvalidFriendTalkImageDimensions, cfg.Width, cfg.Height)
+	}
+
+	return nil
+}
+
+func validateWideFriendTalkImage(cfg image.Config) error {
+	if cfg.Width < MinWideFriendTalkImageWidth || cfg.Height < MinWideFriendTalkImageHeight {
+		return fmt.Errorf("%w: %dx%d, wide images must be at least %dx%d", ErrInvalidFriendTalkImageDimensions, cfg.Width, cfg.Height, MinWideFriendTalkImageWidth, MinWideFriendTalkImageHeight)
+	}
+	// The width must be between 4/3 and 2 times the height.
+	if 3*cfg.Width < 4*cfg.Height || cfg.Width > 2*cfg.Height {
+		return fmt.Errorf("%w: %dx%d, the aspect ratio of wide images must be between 2:1 and 4:3", ErrInvalidFriendTalkImageDimensions, cfg.Width, cfg.Height)
+	}
+
+	return nil
+}
+
+// UploadFriendTalkImage uploads the given image to attach to FriendTalk
+// messages. Wide images are displayed across the whole message bubble.
+// The image is checked with ValidateFriendTalkImage before being uploaded,
+// and the returned image ID can be referenced in the Image of a
+// FriendTalkMessage.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-friendtalkv2
+func (kc *KakaoClient) UploadFriendTalkImage(ctx context.Context, name string, content []byte, wide bool) (UploadFriendTalkImageResponse, error) {
+	if kc.ServiceID == "" {
+		return UploadFriendTalkImageResponse{}, ErrMissingServiceID
+	}
+
+	err := ValidateFriendTalkImage(name, content, wide)
+	if err != nil {
+		return UploadFriendTalkImageResponse{}, err
+	}
+
+	endpoint := kc.BaseURL.JoinPath(FriendTalkImagesEndpoint(kc.ServiceID)).String()
+	req, err := newImageUploadRequest(ctx, endpoint, name, content, wide)
+	if err != nil {
+		return UploadFriendTalkImageResponse{}, fmt.Errorf("could not build the upload image request: %w", err)
+	}
+
+	resp, err := kc.api().do(req)
+	if err != nil {
+		return UploadFriendTalkImageResponse{}, fmt.Errorf("could not perform the HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return UploadFriendTalkImageResponse{}, naverapi.NewAPIError(resp)
+	}
+
+	var responseBody UploadFriendTalkImageResponse
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		return UploadFriendTalkImageResponse{}, fmt.Errorf("could not decode response body: %w", err)
+	}
+
+	return responseBody, nil
+}
+
+func newImageUploadRequest(ctx context.Context, endpoint, name string, content []byte, wide bool) (*http.Request, error) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("imageFile", name)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the form file for %s: %w", name, err)
+	}
+	_, err = part.Write(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy the content of %s into the form: %w", name, err)
+	}
+	err = writer.WriteField("isWide", strconv.FormatBool(wide))
+	if err != nil {
+		return nil, fmt.Errorf("could not write the isWide field: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not close the multipart writer: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the %s HTTP request given the URL %q: %w", http.MethodPost, endpoint, err)
+	}
+	req.Header.Add("Content-Type", writer.FormDataContentType())
+
+	return req, nil
+}
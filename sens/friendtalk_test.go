@@ -0,0 +1,225 @@
+package sens_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"testing"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/connectfit-team/naverapi/internal/testhelper"
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestKakaoClient_SendFriendTalk(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	req := sens.SendFriendTalkRequest{
+		PlusFriendID: "@test_channel",
+		ContentType:  sens.ContentTypeAD,
+		Messages: []sens.FriendTalkMessage{
+			{
+				To:      "01012345678",
+				Content: "봄맞이 할인 이벤트!",
+				Image:   &sens.FriendTalkImage{ImageID: "test-image-id", ImageLink: "https://example.com/events"},
+				Buttons: []sens.Button{sens.WebLinkButton("이벤트 보기", "https://m.example.com/events", "")},
+			},
+		},
+	}
+
+	mux.HandleFunc(sens.FriendTalkMessagesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodPost)
+
+		testhelper.TestRequestHeader(t, r, "Content-Type", "application/json")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Timestamp", "856915200000")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Iam-Access-Key", "test-access-key")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "LyG8hQTZfDfUDNLgD+9LFoIwlT301bDRlbGgT2DyKtw=")
+
+		var got sens.SendFriendTalkRequest
+		err := json.NewDecoder(r.Body).Decode(&got)
+		if err != nil {
+			t.Errorf("could not decode the request body: %v", err)
+		}
+		if diff := cmp.Diff(req, got); diff != "" {
+			t.Errorf("Mismatch between the expected and the sent request (-want +got):\n%s", diff)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusCode":"202","statusName":"success","messages":[{"messageId":"test-message-id","to":"01012345678","requestStatusCode":"A000","requestStatusName":"success"}]}`)
+	})
+
+	got, err := client.SendFriendTalk(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Send FriendTalk request was given a valid request but failed: %v", err)
+	}
+	if got.RequestID != "test-request-id" || len(got.Messages) != 1 || got.Messages[0].MessageID != "test-message-id" {
+		t.Errorf("Unexpected response: %+v", got)
+	}
+}
+
+func TestKakaoClient_SendFriendTalk_ShouldFailIfStatusIsNotSuccess(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.FriendTalkMessagesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusCode":"202","statusName":"fail"}`)
+	})
+
+	_, err := client.SendFriendTalk(context.Background(), sens.SendFriendTalkRequest{})
+	if !errors.Is(err, sens.ErrSendFriendTalkFailed) {
+		t.Fatalf("Expected error %v but got: %v", sens.ErrSendFriendTalkFailed, err)
+	}
+	var apiErr *naverapi.APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != "test-request-id" {
+		t.Errorf("Expected an API error with the request ID but got: %v", err)
+	}
+}
+
+func TestKakaoClient_GetFriendTalkMessageResult(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.FriendTalkMessageEndpoint(testKakaoServiceID, "test-message-id"), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, `{"requestId":"test-request-id","plusFriendId":"@test_channel","to":"01012345678","messageStatusCode":"0000","messageStatusName":"success","image":{"imageId":"test-image-id"}}`)
+	})
+
+	got, err := client.GetFriendTalkMessageResult(context.Background(), "test-message-id")
+	if err != nil {
+		t.Fatalf("Get FriendTalk message result request was given a valid request but failed: %v", err)
+	}
+	if got.MessageID != "test-message-id" || !got.Delivered() || got.Image == nil || got.Image.ImageID != "test-image-id" {
+		t.Errorf("Unexpected result: %+v", got)
+	}
+}
+
+func TestKakaoClient_ListFriendTalkMessages(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.FriendTalkMessagesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		if got := r.URL.Query().Get("requestId"); got != "test-request-id" {
+			t.Errorf("Expected requestId %q but got %q", "test-request-id", got)
+		}
+
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusCode":"202","statusName":"success","messages":[{"messageId":"test-message-id","messageStatusCode":"3015"}]}`)
+	})
+
+	got, err := client.ListFriendTalkMessages(context.Background(), "test-request-id")
+	if err != nil {
+		t.Fatalf("List FriendTalk messages request was given a valid request but failed: %v", err)
+	}
+	if len(got.Messages) != 1 || got.Messages[0].Delivered() {
+		t.Errorf("Expected a single undelivered message but got: %+v", got.Messages)
+	}
+}
+
+func TestKakaoClient_UploadFriendTalkImage(t *testing.T) {
+	client, mux, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	content := newTestPNG(t, 800, 600)
+
+	mux.HandleFunc(sens.FriendTalkImagesEndpoint(testKakaoServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodPost)
+
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "jAv22fEuOUVT/QU/rn1Kciqdo/uONOn5X3SEScYxHH4=")
+		testhelper.TestRequestFormFiles(t, r, "imageFile", []string{string(content)})
+
+		if got := r.FormValue("isWide"); got != "true" {
+			t.Errorf("Expected isWide %q but got %q", "true", got)
+		}
+
+		fmt.Fprint(w, `{"imageId":"test-image-id","imageName":"event.png","imageUrl":"https://example.com/event.png","isWide":true}`)
+	})
+
+	got, err := client.UploadFriendTalkImage(context.Background(), "event.png", content, true)
+	if err != nil {
+		t.Fatalf("Upload FriendTalk image request was given a valid request but failed: %v", err)
+	}
+
+	want := sens.UploadFriendTalkImageResponse{
+		ImageID:   "test-image-id",
+		ImageName: "event.png",
+		ImageURL:  "https://example.com/event.png",
+		IsWide:    true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Mismatch between the expected and the returned response (-want +got):\n%s", diff)
+	}
+}
+
+func newTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)))
+	if err != nil {
+		t.Fatalf("could not encode the test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestValidateFriendTalkImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		wide     bool
+		wantErr  error
+	}{
+		{name: "valid PNG", filename: "event.png", content: newTestPNG(t, 800, 600)},
+		{name: "valid JPEG", filename: "event.JPG", content: newTestJPEG(t, 720, 720)},
+		{name: "tallest image", filename: "event.png", content: newTestPNG(t, 600, 800)},
+		{name: "widest image", filename: "event.png", content: newTestPNG(t, 1000, 500)},
+		{name: "unsupported extension", filename: "event.gif", content: newTestPNG(t, 800, 600), wantErr: sens.ErrInvalidFriendTalkImageFormat},
+		{name: "extension mismatch", filename: "event.jpg", content: newTestPNG(t, 800, 600), wantErr: sens.ErrInvalidFriendTalkImageFormat},
+		{name: "not an image", filename: "event.png", content: []byte("test-image-content"), wantErr: sens.ErrInvalidFriendTalkImageFormat},
+		{name: "too large", filename: "event.png", content: make([]byte, sens.MaxFriendTalkImageSize+1), wantErr: sens.ErrFriendTalkImageTooLarge},
+		{name: "too narrow", filename: "event.png", content: newTestPNG(t, 400, 300), wantErr: sens.ErrInvalidFriendTalkImageDimensions},
+		{name: "too tall", filename: "event.png", content: newTestPNG(t, 600, 900), wantErr: sens.ErrInvalidFriendTalkImageDimensions},
+		{name: "too wide", filename: "event.jpg", content: newTestJPEG(t, 1200, 500), wantErr: sens.ErrInvalidFriendTalkImageDimensions},
+		{name: "valid wide image", filename: "event.png", content: newTestPNG(t, 800, 600), wide: true},
+		{name: "widest wide image", filename: "event.png", content: newTestPNG(t, 1200, 600), wide: true},
+		{name: "wide image too small", filename: "event.png", content: newTestPNG(t, 600, 450), wide: true, wantErr: sens.ErrInvalidFriendTalkImageDimensions},
+		{name: "wide image too tall", filename: "event.png", content: newTestPNG(t, 800, 800), wide: true, wantErr: sens.ErrInvalidFriendTalkImageDimensions},
+		{name: "wide image too wide", filename: "event.jpg", content: newTestJPEG(t, 1300, 600), wide: true, wantErr: sens.ErrInvalidFriendTalkImageDimensions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sens.ValidateFriendTalkImage(tt.filename, tt.content, tt.wide)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v but got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestKakaoClient_GetFriendTalkMessageResult_ShouldFailIfNoMessageID(t *testing.T) {
+	client, _, teardown := setupTestKakaoClient()
+	defer teardown()
+
+	_, err := client.GetFriendTalkMessageResult(context.Background(), "")
+	if !errors.Is(err, sens.ErrMissingID) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMissingID, err)
+	}
+}
+
+func TestFriendTalkMessageEndpoint_ShouldEscapeTheMessageID(t *testing.T) {
+	got := sens.FriendTalkMessageEndpoint(testKakaoServiceID, "test/message")
+	want := sens.FriendTalkMessagesEndpoint(testKakaoServiceID) + "/test%2Fmessage"
+	if got != want {
+		t.Errorf("Expected the path %q but got %q", want, got)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/connectfit-team/naverapi/sens"
 )
 
+const testServiceID = "ncp:sms:kr:123456789012:test_service"
+
 type fixedTimeClock struct {
 	fixedTime time.Time
 }
@@ -24,6 +26,51 @@ func setupTestSENSClient() (client *sens.Client, mux *http.ServeMux, teardown fu
 	client, _ = sens.NewClient(
 		"test-access-key",
 		"test-secret-key",
+		testServiceID,
+		srv.Client(),
+	)
+	client.Clock = &fixedTimeClock{
+		fixedTime: time.Date(1997, 02, 26, 0, 0, 0, 0, time.UTC),
+	}
+	client.BaseURL = srvURL
+
+	return client, mux, srv.Close
+}
+
+const testKakaoServiceID = "ncp:kkobizmsg:kr:1234567:test_channel"
+
+func setupTestKakaoClient() (client *sens.KakaoClient, mux *http.ServeMux, teardown func()) {
+	mux = http.NewServeMux()
+
+	srv := httptest.NewServer(mux)
+
+	srvURL, _ := url.Parse(srv.URL)
+	client, _ = sens.NewKakaoClient(
+		"test-access-key",
+		"test-secret-key",
+		testKakaoServiceID,
+		srv.Client(),
+	)
+	client.Clock = &fixedTimeClock{
+		fixedTime: time.Date(1997, 02, 26, 0, 0, 0, 0, time.UTC),
+	}
+	client.BaseURL = srvURL
+
+	return client, mux, srv.Close
+}
+
+const testPushServiceID = "ncp:push:kr:1234567:test_app"
+
+func setupTestPushClient() (client *sens.PushClient, mux *http.ServeMux, teardown func()) {
+	mux = http.NewServeMux()
+
+	srv := httptest.NewServer(mux)
+
+	srvURL, _ := url.Parse(srv.URL)
+	client, _ = sens.NewPushClient(
+		"test-access-key",
+		"test-secret-key",
+		testPushServiceID,
 		srv.Client(),
 	)
 	client.Clock = &fixedTimeClock{
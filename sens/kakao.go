@@ -0,0 +1,36 @@
+package sens
+
+import "net/http"
+
+// KakaoClient is a REST client providing methods to send KakaoTalk messages
+// through the Naver Cloud Platform SENS Biz Message API.
+// It is configured as Client, but with the ID of a Biz Message service.
+type KakaoClient struct {
+	ClientConfig
+	// ValidateTemplates, if set, makes SendAlimTalk look up the template of
+	// each request with GetAlimTalkTemplate and check the request with
+	// AlimTalkTemplate.Validate before sending it. Otherwise the requests are
+	// only checked by the API, and callers may validate them themselves.
+	ValidateTemplates bool
+}
+
+// NewKakaoClient returns a new Naver Cloud Platform Biz Message API client
+// given an access key and a secret key to authenticate to the API and the ID
+// of the SENS Biz Message service (project) to send the messages from.
+// It uses the http.DefaultClient unless you provide your own.
+func NewKakaoClient(accessKey, secretKey, serviceID string, httpClient *http.Client) (*KakaoClient, error) {
+	cfg, err := newClientConfig(accessKey, secretKey, serviceID, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KakaoClient{ClientConfig: cfg}, nil
+}
+
+// WithServiceID returns a copy of the client sending its requests to the
+// SENS Biz Message service identified by the given service ID.
+func (kc *KakaoClient) WithServiceID(serviceID string) *KakaoClient {
+	c := *kc
+	c.ServiceID = serviceID
+	return &c
+}
@@ -0,0 +1,139 @@
+package sens
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// ErrMessageNotFound is returned when looking up the result of a message which
+// does not exist.
+var ErrMessageNotFound = errors.New("message not found")
+
+// MessageStatus is the sending status of a message.
+type MessageStatus string
+
+const (
+	MessageStatusReady      MessageStatus = "READY"      // 발송 대기
+	MessageStatusProcessing MessageStatus = "PROCESSING" // 발송 중
+	MessageStatusCompleted  MessageStatus = "COMPLETED"  // 발송 완료
+)
+
+// Telco is the telecommunication company a message has been delivered
+// through.
+type Telco string
+
+const (
+	TelcoSKT Telco = "SKT"
+	TelcoKT  Telco = "KT"
+	TelcoLGT Telco = "LGT"
+)
+
+// MessageResult represents the result of a message sent to a single
+// recipient.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+type MessageResult struct {
+	MessageID     string         `json:"messageId"`
+	RequestTime   Time           `json:"requestTime"`
+	ContentType   SMSContentType `json:"contentType"`
+	Content       string         `json:"content,omitempty"` // 메시지 조회 시에만 반환
+	CountryCode   SMSCountryCode `json:"countryCode"`
+	From          string         `json:"from"`
+	To            string         `json:"to"`
+	Status        MessageStatus  `json:"status"`        // 발송 상태 (READY | PROCESSING | COMPLETED)
+	StatusCode    string         `json:"statusCode"`    // 발송 결과 코드 (0: 성공)
+	StatusName    string         `json:"statusName"`    // 발송 결과 (success | fail)
+	StatusMessage string         `json:"statusMessage"` // 발송 결과 메시지
+	CompleteTime  Time           `json:"completeTime"`  // 발송 완료 시간
+	TelcoCode     Telco          `json:"telcoCode"`     // 통신사 코드 (SKT | KT | LGT)
+}
+
+// Delivered reports whether the message has been delivered to its recipient.
+func (mr MessageResult) Delivered() bool {
+	return mr.Status == MessageStatusCompleted && mr.StatusName == "success"
+}
+
+// ListMessagesResponse represents the response sent by the SENS SMS API after
+// a request to list the messages of a send request.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+type ListMessagesResponse struct {
+	RequestID  string          `json:"requestId"`
+	StatusCode string          `json:"statusCode"`
+	StatusName string          `json:"statusName"`
+	Messages   []MessageResult `json:"messages"`
+}
+
+// getMessageResponse represents the response sent by the SENS SMS API after a
+// request to get the result of a message.
+type getMessageResponse struct {
+	StatusCode string          `json:"statusCode"`
+	StatusName string          `json:"statusName"`
+	Messages   []MessageResult `json:"messages"`
+}
+
+// MessageEndpoint returns the path of the endpoint of the message identified
+// by the given message ID in the SENS SMS service identified by the given
+// service ID.
+func MessageEndpoint(serviceID, messageID string) string {
+	return path.Join(MessagesEndpoint(serviceID), url.PathEscape(messageID))
+}
+
+// ListMessages lists the messages sent by the send request identified by the
+// given request ID, as returned in SendSMSResponse, with the result of each
+// of them.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+func (ss *Client) ListMessages(ctx context.Context, requestID string) (ListMessagesResponse, error) {
+	if ss.ServiceID == "" {
+		return ListMessagesResponse{}, ErrMissingServiceID
+	}
+	err := checkID("request ID", requestID)
+	if err != nil {
+		return ListMessagesResponse{}, err
+	}
+
+	query := url.Values{}
+	query.Set("requestId", requestID)
+
+	var resp ListMessagesResponse
+	err = ss.api().doJSON(ctx, http.MethodGet, MessagesEndpoint(ss.ServiceID), query, nil, &resp)
+	if err != nil {
+		return ListMessagesResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// GetMessageResult returns the result of the message identified by the given
+// message ID, as returned by ListMessages.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+func (ss *Client) GetMessageResult(ctx context.Context, messageID string) (MessageResult, error) {
+	if ss.ServiceID == "" {
+		return MessageResult{}, ErrMissingServiceID
+	}
+	err := checkID("message ID", messageID)
+	if err != nil {
+		return MessageResult{}, err
+	}
+
+	var resp getMessageResponse
+	err = ss.api().doJSON(ctx, http.MethodGet, MessageEndpoint(ss.ServiceID, messageID), nil, nil, &resp)
+	if err != nil {
+		return MessageResult{}, err
+	}
+
+	if len(resp.Messages) == 0 {
+		return MessageResult{}, ErrMessageNotFound
+	}
+	result := resp.Messages[0]
+	if result.MessageID == "" {
+		result.MessageID = messageID
+	}
+
+	return result, nil
+}
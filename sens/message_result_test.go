@@ -0,0 +1,188 @@
+package sens_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi/internal/testhelper"
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+const validListMessagesResponse = `
+	{
+		"requestId": "test-request-id",
+		"statusCode": "202",
+		"statusName": "success",
+		"messages": [
+			{
+				"messageId": "test-message-id",
+				"requestTime": "2023-03-02 10:15:00",
+				"contentType": "COMM",
+				"countryCode": "82",
+				"from": "0212345678",
+				"to": "01012345678",
+				"status": "COMPLETED",
+				"statusCode": "0",
+				"statusName": "success",
+				"statusMessage": "성공",
+				"completeTime": "2023-03-02 10:15:03",
+				"telcoCode": "SKT"
+			}
+		]
+	}
+`
+
+const validGetMessageResponse = `
+	{
+		"statusCode": "202",
+		"statusName": "success",
+		"messages": [
+			{
+				"requestTime": "2023-03-02 10:15:00",
+				"contentType": "COMM",
+				"content": "test-content",
+				"countryCode": "82",
+				"from": "0212345678",
+				"to": "01012345678",
+				"status": "COMPLETED",
+				"statusCode": "3018",
+				"statusName": "fail",
+				"statusMessage": "전원 꺼짐",
+				"completeTime": "2023-03-02 10:15:03",
+				"telcoCode": "KT"
+			}
+		]
+	}
+`
+
+func TestSENSClient_ListMessages(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Timestamp", "856915200000")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Iam-Access-Key", "test-access-key")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "rE8emkci6LNNnGNfawaUA5bWPzOH8bEryDhr2ehoPk8=")
+
+		if got := r.URL.Query().Get("requestId"); got != "test-request-id" {
+			t.Errorf("Expected requestId %q but got %q", "test-request-id", got)
+		}
+
+		fmt.Fprint(w, validListMessagesResponse)
+	})
+
+	got, err := client.ListMessages(context.Background(), "test-request-id")
+	if err != nil {
+		t.Fatalf("List messages request was given a valid request but failed: %v", err)
+	}
+
+	want := sens.ListMessagesResponse{
+		RequestID:  "test-request-id",
+		StatusCode: "202",
+		StatusName: "success",
+		Messages: []sens.MessageResult{
+			{
+				MessageID:     "test-message-id",
+				RequestTime:   sens.Time{Time: time.Date(2023, 3, 2, 10, 15, 0, 0, sens.KST)},
+				ContentType:   sens.ContentTypeSMS,
+				CountryCode:   sens.CountryCodeKorea,
+				From:          "0212345678",
+				To:            "01012345678",
+				Status:        sens.MessageStatusCompleted,
+				StatusCode:    "0",
+				StatusName:    "success",
+				StatusMessage: "성공",
+				CompleteTime:  sens.Time{Time: time.Date(2023, 3, 2, 10, 15, 3, 0, sens.KST)},
+				TelcoCode:     sens.TelcoSKT,
+			},
+		},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Response differ from the expected one: %s", diff)
+	}
+	if !got.Messages[0].Delivered() {
+		t.Errorf("Expected the message to be delivered")
+	}
+}
+
+func TestSENSClient_GetMessageResult(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.MessageEndpoint(testServiceID, "test-message-id"), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "SSm95ND2BpnoVFb4biyuE5yiubpyY/wOEk/yBhmIx0s=")
+
+		fmt.Fprint(w, validGetMessageResponse)
+	})
+
+	got, err := client.GetMessageResult(context.Background(), "test-message-id")
+	if err != nil {
+		t.Fatalf("Get message request was given a valid request but failed: %v", err)
+	}
+
+	if got.MessageID != "test-message-id" || got.TelcoCode != sens.TelcoKT || got.StatusCode != "3018" {
+		t.Errorf("Unexpected message result: %+v", got)
+	}
+	if got.Delivered() {
+		t.Errorf("Expected the message not to be delivered")
+	}
+}
+
+func TestSENSClient_GetMessageResult_ShouldFailIfNotFound(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.MessageEndpoint(testServiceID, "test-message-id"), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"statusCode":"202","statusName":"success","messages":[]}`)
+	})
+
+	_, err := client.GetMessageResult(context.Background(), "test-message-id")
+	if !errors.Is(err, sens.ErrMessageNotFound) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMessageNotFound, err)
+	}
+}
+
+func TestSENSClient_GetMessageResult_ShouldEscapeTheMessageID(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	var gotPath string
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID)+"/", func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		fmt.Fprint(w, validGetMessageResponse)
+	})
+
+	_, err := client.GetMessageResult(context.Background(), "test/message?id")
+	if err != nil {
+		t.Fatalf("Get message request was given a valid request but failed: %v", err)
+	}
+
+	want := sens.MessagesEndpoint(testServiceID) + "/test%2Fmessage%3Fid"
+	if gotPath != want {
+		t.Errorf("Expected the path %q but got %q", want, gotPath)
+	}
+}
+
+func TestSENSClient_GetMessageResult_ShouldFailIfNoMessageID(t *testing.T) {
+	client, _, teardown := setupTestSENSClient()
+	defer teardown()
+
+	_, err := client.GetMessageResult(context.Background(), "")
+	if !errors.Is(err, sens.ErrMissingID) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMissingID, err)
+	}
+
+	_, err = client.ListMessages(context.Background(), "")
+	if !errors.Is(err, sens.ErrMissingID) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMissingID, err)
+	}
+}
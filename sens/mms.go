@@ -0,0 +1,114 @@
+package sens
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image/jpeg"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// MaxMMSImageSize is the maximum size in bytes of an MMS image.
+	MaxMMSImageSize = 300 * 1024
+	// MaxMMSImageWidth is the maximum width in pixels of an MMS image.
+	MaxMMSImageWidth = 1500
+	// MaxMMSImageHeight is the maximum height in pixels of an MMS image.
+	MaxMMSImageHeight = 1440
+)
+
+var (
+	// ErrInvalidMMSImageFormat is returned when an MMS image is not a JPEG.
+	ErrInvalidMMSImageFormat = errors.New("MMS images must be JPEG files")
+	// ErrMMSImageTooLarge is returned when an MMS image exceeds
+	// MaxMMSImageSize.
+	ErrMMSImageTooLarge = errors.New("the MMS image is too large")
+	// ErrMMSImageResolutionTooHigh is returned when an MMS image exceeds
+	// MaxMMSImageWidth or MaxMMSImageHeight.
+	ErrMMSImageResolutionTooHigh = errors.New("the MMS image resolution is too high")
+)
+
+// File references a file uploaded with UploadFile in a SendSMSRequest.
+type File struct {
+	FileID string `json:"fileId"` // 파일 아이디 - 필수
+}
+
+// UploadFileRequest represents the REST request to upload a file.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+type UploadFileRequest struct {
+	FileName string `json:"fileName"` // 파일 이름(jpg, jpeg 확장자만 지원) - 필수
+	FileBody string `json:"fileBody"` // Base64 인코딩된 파일 내용 - 필수
+}
+
+// UploadFileResponse represents the response sent by the SENS SMS API after
+// a request to upload a file.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+type UploadFileResponse struct {
+	FileID string `json:"fileId"`
+}
+
+// FilesEndpoint returns the path of the files endpoint of the SENS SMS service
+// identified by the given service ID.
+func FilesEndpoint(serviceID string) string {
+	return path.Join(EndpointSMSServices, serviceID, "files")
+}
+
+// ValidateMMSImage checks that the given file can be sent as an MMS image:
+// a JPEG file of at most MaxMMSImageSize bytes and MaxMMSImageWidth x
+// MaxMMSImageHeight pixels.
+func ValidateMMSImage(name string, content []byte) error {
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext != ".jpg" && ext != ".jpeg" {
+		return fmt.Errorf("%w: unsupported extension %q", ErrInvalidMMSImageFormat, ext)
+	}
+
+	if len(content) > MaxMMSImageSize {
+		return fmt.Errorf("%w: %d bytes, at most %d bytes are allowed", ErrMMSImageTooLarge, len(content), MaxMMSImageSize)
+	}
+
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMMSImageFormat, err)
+	}
+	if cfg.Width > MaxMMSImageWidth || cfg.Height > MaxMMSImageHeight {
+		return fmt.Errorf("%w: %dx%d, at most %dx%d is allowed", ErrMMSImageResolutionTooHigh, cfg.Width, cfg.Height, MaxMMSImageWidth, MaxMMSImageHeight)
+	}
+
+	return nil
+}
+
+// UploadFile uploads the given JPEG image to attach to an MMS.
+// The image is checked with ValidateMMSImage before being uploaded, and the
+// returned file ID can be referenced in the Files of a SendSMSRequest.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+func (ss *Client) UploadFile(ctx context.Context, name string, content []byte) (UploadFileResponse, error) {
+	if ss.ServiceID == "" {
+		return UploadFileResponse{}, ErrMissingServiceID
+	}
+
+	err := ValidateMMSImage(name, content)
+	if err != nil {
+		return UploadFileResponse{}, err
+	}
+
+	req := UploadFileRequest{
+		FileName: name,
+		FileBody: base64.StdEncoding.EncodeToString(content),
+	}
+
+	var resp UploadFileResponse
+	err = ss.api().doJSON(ctx, http.MethodPost, FilesEndpoint(ss.ServiceID), nil, req, &resp)
+	if err != nil {
+		return UploadFileResponse{}, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,121 @@
+package sens_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"net/http"
+	"testing"
+
+	"github.com/connectfit-team/naverapi/internal/testhelper"
+	"github.com/connectfit-team/naverapi/sens"
+)
+
+func newTestJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil)
+	if err != nil {
+		t.Fatalf("could not encode the test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestValidateMMSImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		wantErr  error
+	}{
+		{
+			name:     "valid image",
+			filename: "coupon.jpg",
+			content:  newTestJPEG(t, 640, 480),
+		},
+		{
+			name:     "unsupported extension",
+			filename: "coupon.png",
+			content:  newTestJPEG(t, 640, 480),
+			wantErr:  sens.ErrInvalidMMSImageFormat,
+		},
+		{
+			name:     "not a JPEG",
+			filename: "coupon.jpeg",
+			content:  []byte("not a JPEG :)"),
+			wantErr:  sens.ErrInvalidMMSImageFormat,
+		},
+		{
+			name:     "too large",
+			filename: "coupon.jpg",
+			content:  make([]byte, sens.MaxMMSImageSize+1),
+			wantErr:  sens.ErrMMSImageTooLarge,
+		},
+		{
+			name:     "resolution too high",
+			filename: "coupon.jpg",
+			content:  newTestJPEG(t, sens.MaxMMSImageWidth+1, 10),
+			wantErr:  sens.ErrMMSImageResolutionTooHigh,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sens.ValidateMMSImage(tt.filename, tt.content)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v but got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSENSClient_UploadFile(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	content := newTestJPEG(t, 640, 480)
+
+	mux.HandleFunc(sens.FilesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodPost)
+		testhelper.TestRequestHeader(t, r, "Content-Type", "application/json")
+
+		var req sens.UploadFileRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			t.Errorf("could not decode the request body: %v", err)
+		}
+		if req.FileName != "coupon.jpg" || req.FileBody != base64.StdEncoding.EncodeToString(content) {
+			t.Errorf("Unexpected upload file request: %s", req.FileName)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"fileId":"test-file-id"}`)
+	})
+
+	got, err := client.UploadFile(context.Background(), "coupon.jpg", content)
+	if err != nil {
+		t.Fatalf("Upload file request was given a valid request but failed: %v", err)
+	}
+	if got.FileID != "test-file-id" {
+		t.Errorf("Expected file ID %q but got %q", "test-file-id", got.FileID)
+	}
+}
+
+func TestSENSClient_UploadFile_ShouldNotUploadInvalidImage(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.FilesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("An invalid image shouldn't be uploaded")
+	})
+
+	_, err := client.UploadFile(context.Background(), "coupon.gif", []byte("GIF89a"))
+	if !errors.Is(err, sens.ErrInvalidMMSImageFormat) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrInvalidMMSImageFormat, err)
+	}
+}
@@ -0,0 +1,340 @@
+package sens_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi/internal/testhelper"
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSENSClient_ListOptOuts(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.OptOutsEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+		if got := r.URL.Query().Get("clientTelNo"); got != "01012345678" {
+			t.Errorf("Expected clientTelNo %q but got %q", "01012345678", got)
+		}
+
+		fmt.Fprint(w, `[{"clientTelNo":"01012345678","registerTime":"1997-02-25 10:15:00"}]`)
+	})
+
+	got, err := client.ListOptOuts(context.Background(), sens.ListOptOutsRequest{Number: "01012345678"})
+	if err != nil {
+		t.Fatalf("List opt-outs request was given a valid request but failed: %v", err)
+	}
+
+	want := []sens.OptOut{{Number: "01012345678", RegisterTime: "1997-02-25 10:15:00"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Mismatch between the expected and the returned opt-outs (-want +got):\n%s", diff)
+	}
+}
+
+func TestSENSClient_AddOptOut(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.OptOutsEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodPost)
+		testhelper.TestRequestBody(t, r, `{"clientTelNo":"01012345678"}`)
+
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := client.AddOptOut(context.Background(), "01012345678")
+	if err != nil {
+		t.Fatalf("Add opt-out request was given a valid request but failed: %v", err)
+	}
+}
+
+func TestSENSClient_RemoveOptOut(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.OptOutsEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodDelete)
+		if got := r.URL.Query().Get("clientTelNo"); got != "01012345678" {
+			t.Errorf("Expected clientTelNo %q but got %q", "01012345678", got)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.RemoveOptOut(context.Background(), "01012345678")
+	if err != nil {
+		t.Fatalf("Remove opt-out request was given a valid request but failed: %v", err)
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldDropOptedOutRecipients(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.OptOuts = &sens.OptOutRegistry{}
+
+	mux.HandleFunc(sens.OptOutsEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"clientTelNo":"010-1234-5678","registerTime":"1997-02-25 10:15:00"}]`)
+	})
+
+	var got sens.SendSMSRequest
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("Could not decode the request body: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusName":"success"}`)
+	})
+
+	req := sens.SendSMSRequest{
+		Type:        sens.SMSTypeSMS,
+		ContentType: sens.ContentTypeAD,
+		Content:     "(광고)sale",
+		Messages:    []sens.Message{{To: "01012345678"}, {To: "01087654321"}},
+	}
+
+	resp, err := client.SendSMS(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Send SMS request was given a valid request but failed: %v", err)
+	}
+
+	if diff := cmp.Diff([]sens.Message{{To: "01087654321"}}, got.Messages); diff != "" {
+		t.Errorf("Unexpected sent messages (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]sens.Message{{To: "01012345678"}}, resp.OptedOut); diff != "" {
+		t.Errorf("Unexpected opted out messages (-want +got):\n%s", diff)
+	}
+
+	req.Messages = req.Messages[:1]
+	resp, err = client.SendSMS(context.Background(), req)
+	if !errors.Is(err, sens.ErrAllRecipientsOptedOut) {
+		t.Fatalf("Expected error %v but got: %v", sens.ErrAllRecipientsOptedOut, err)
+	}
+	if len(resp.OptedOut) != 1 {
+		t.Errorf("Expected 1 opted out message but got %v", resp.OptedOut)
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldNotFilterNonAdvertisements(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.OptOuts = &sens.OptOutRegistry{}
+
+	mux.HandleFunc(sens.OptOutsEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		t.Error("The opt-outs should not be listed for a non advertisement")
+	})
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusName":"success"}`)
+	})
+
+	_, err := client.SendSMS(context.Background(), bulkRequest(2))
+	if err != nil {
+		t.Fatalf("Send SMS request was given a valid request but failed: %v", err)
+	}
+}
+
+func TestSENSClient_SendBulk_ShouldDropOptedOutRecipients(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.OptOuts = &sens.OptOutRegistry{}
+
+	var (
+		mu       sync.Mutex
+		listings int
+		sent     int
+	)
+	mux.HandleFunc(sens.OptOutsEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		listings++
+		mu.Unlock()
+		fmt.Fprint(w, `[{"clientTelNo":"01000000001"},{"clientTelNo":"+821000000150"}]`)
+	})
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		var req sens.SendSMSRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("could not decode the request body: %v", err)
+		}
+		mu.Lock()
+		sent += len(req.Messages)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusName":"success"}`)
+	})
+
+	req := bulkRequest(200)
+	req.ContentType = sens.ContentTypeAD
+
+	got, err := client.SendBulk(context.Background(), req, sens.BulkOptions{})
+	if err != nil {
+		t.Fatalf("Send bulk request was given a valid request but failed: %v", err)
+	}
+
+	if listings != 1 {
+		t.Errorf("Expected the opt-outs to be listed once but they were listed %d times", listings)
+	}
+	if sent != 198 || len(got.Recipients) != 198 {
+		t.Errorf("Expected 198 messages to be sent but %d were sent for %d recipients", sent, len(got.Recipients))
+	}
+	want := []sens.Message{{To: "01000000001"}, {To: "01000000150"}}
+	if diff := cmp.Diff(want, got.OptedOut); diff != "" {
+		t.Errorf("Unexpected opted out messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldReportOptedOutRecipientsOnFailure(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.OptOuts = &sens.OptOutRegistry{}
+
+	mux.HandleFunc(sens.OptOutsEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"clientTelNo":"01012345678"}]`)
+	})
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"errorCode":"400","message":"invalid request"}}`)
+	})
+
+	req := sens.SendSMSRequest{
+		Type:        sens.SMSTypeSMS,
+		ContentType: sens.ContentTypeAD,
+		Content:     "(광고)sale",
+		Messages:    []sens.Message{{To: "01012345678"}, {To: "01087654321"}},
+	}
+
+	resp, err := client.SendSMS(context.Background(), req)
+	if err == nil {
+		t.Fatal("Expected the send SMS request to fail")
+	}
+	if diff := cmp.Diff([]sens.Message{{To: "01012345678"}}, resp.OptedOut); diff != "" {
+		t.Errorf("Unexpected opted out messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldCompareOptOutsAsKoreanNumbers(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.OptOuts = &sens.OptOutRegistry{}
+
+	mux.HandleFunc(sens.OptOutsEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"clientTelNo":"01012345678"}]`)
+	})
+	var got sens.SendSMSRequest
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("Could not decode the request body: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusName":"success"}`)
+	})
+
+	req := sens.SendSMSRequest{
+		Type:        sens.SMSTypeSMS,
+		ContentType: sens.ContentTypeAD,
+		CountryCode: "1",
+		Content:     "(광고)sale",
+		Messages:    []sens.Message{{To: "01012345678"}},
+	}
+
+	resp, err := client.SendSMS(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Send SMS request was given a valid request but failed: %v", err)
+	}
+	if len(resp.OptedOut) != 0 || len(got.Messages) != 1 {
+		t.Errorf("Expected the foreign recipient to be kept but got %v opted out", resp.OptedOut)
+	}
+
+	req.CountryCode = "82"
+	req.Messages = []sens.Message{{To: "+82 10-1234-5678"}}
+	_, err = client.SendSMS(context.Background(), req)
+	if !errors.Is(err, sens.ErrAllRecipientsOptedOut) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrAllRecipientsOptedOut, err)
+	}
+}
+
+func TestSENSClient_SendBulk_ShouldFailIfEveryRecipientOptedOut(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.OptOuts = &sens.OptOutRegistry{}
+
+	mux.HandleFunc(sens.OptOutsEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"clientTelNo":"01000000000"},{"clientTelNo":"01000000001"}]`)
+	})
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		t.Error("A bulk request without recipients shouldn't be sent")
+	})
+
+	req := bulkRequest(2)
+	req.ContentType = sens.ContentTypeAD
+
+	got, err := client.SendBulk(context.Background(), req, sens.BulkOptions{})
+	if !errors.Is(err, sens.ErrAllRecipientsOptedOut) {
+		t.Fatalf("Expected error %v but got: %v", sens.ErrAllRecipientsOptedOut, err)
+	}
+	if len(got.OptedOut) != 2 {
+		t.Errorf("Expected 2 opted out messages but got %v", got.OptedOut)
+	}
+}
+
+func TestSENSClient_FilterOptedOut_ShouldCacheTheOptOuts(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.OptOuts = &sens.OptOutRegistry{TTL: time.Hour}
+
+	listings := 0
+	mux.HandleFunc(sens.OptOutsEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		listings++
+		fmt.Fprint(w, `[{"clientTelNo":"01012345678","registerTime":"1997-02-25 10:15:00"}]`)
+	})
+
+	req := sens.SendSMSRequest{
+		ContentType: sens.ContentTypeAD,
+		Messages:    []sens.Message{{To: "01012345678"}, {To: "01087654321"}},
+	}
+	for i := 0; i < 2; i++ {
+		_, dropped, err := client.FilterOptedOut(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Filtering the opted out recipients failed: %v", err)
+		}
+		if len(dropped) != 1 {
+			t.Errorf("Expected 1 dropped message but got %v", dropped)
+		}
+	}
+	if listings != 1 {
+		t.Errorf("Expected the opt-outs to be listed once but they were listed %d times", listings)
+	}
+
+	client.Clock = &fixedTimeClock{fixedTime: time.Date(1997, 2, 26, 1, 0, 0, 0, time.UTC)}
+	_, _, _ = client.FilterOptedOut(context.Background(), req)
+	if listings != 2 {
+		t.Errorf("Expected the opt-outs to be listed again once expired but they were listed %d times", listings)
+	}
+
+	err := client.AddOptOut(context.Background(), "01087654321")
+	if err != nil {
+		t.Fatalf("Add opt-out request was given a valid request but failed: %v", err)
+	}
+	_, _, _ = client.FilterOptedOut(context.Background(), req)
+	if listings != 3 {
+		t.Errorf("Expected the opt-outs to be listed again once one was added but they were listed %d times", listings)
+	}
+}
@@ -0,0 +1,268 @@
+package sens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidPhoneNumber is returned when a phone number can't be parsed.
+	ErrInvalidPhoneNumber = errors.New("invalid phone number")
+	// ErrMixedCountryCodes is returned when normalizing a request whose
+	// recipients are in several countries, which must be sent separately.
+	ErrMixedCountryCodes = errors.New("the recipients have different country codes")
+)
+
+// callingCodes holds the assigned country calling codes, which are prefix
+// free: no code is the prefix of another one.
+var callingCodes = func() map[string]bool {
+	codes := make(map[string]bool)
+	for _, c := range strings.Fields(`
+		1 7
+		20 27 30 31 32 33 34 36 39 40 41 43 44 45 46 47 48 49 51 52 53 54 55 56 57 58
+		60 61 62 63 64 65 66 81 82 84 86 90 91 92 93 94 95 98
+		211 212 213 216 218 220 221 222 223 224 225 226 227 228 229 230 231 232 233
+		234 235 236 237 238 239 240 241 242 243 244 245 246 247 248 249 250 251 252
+		253 254 255 256 257 258 260 261 262 263 264 265 266 267 268 269 290 291 297
+		298 299 350 351 352 353 354 355 356 357 358 359 370 371 372 373 374 375 376
+		377 378 379 380 381 382 383 385 386 387 389 420 421 423 500 501 502 503 504
+		505 506 507 508 509 590 591 592 593 594 595 596 597 598 599 670 672 673 674
+		675 676 677 678 679 680 681 682 683 685 686 687 688 689 690 691 692 850 852
+		853 855 856 880 886 960 961 962 963 964 965 966 967 968 970 971 972 973 974
+		975 976 977 992 993 994 995 996 998
+	`) {
+		codes[c] = true
+	}
+	return codes
+}()
+
+// PhoneNumber is a phone number split into the country code and the number
+// expected by the SENS API.
+type PhoneNumber struct {
+	// CountryCode is the country calling code, e.g. "82".
+	CountryCode SMSCountryCode
+	// Number holds the digits of the number without the country code.
+	// Korean numbers keep their leading 0, as dialed in Korea, e.g.
+	// "01012345678".
+	Number string
+}
+
+// ParsePhoneNumber parses a Korean phone number (010-1234-5678, 02-123-4567,
+// 1588-1234, +82 10-1234-5678, 821012345678...) or an international number
+// in the E.164 format (+1 202-555-0123).
+// Spaces, hyphens, dots and parentheses are ignored.
+// It returns an error wrapping ErrInvalidPhoneNumber if the number is
+// malformed.
+func ParsePhoneNumber(s string) (PhoneNumber, error) {
+	digits, international, err := phoneDigits(s)
+	if err != nil {
+		return PhoneNumber{}, err
+	}
+
+	switch {
+	case international:
+		return parseInternationalNumber(s, digits)
+	case strings.HasPrefix(digits, string(CountryCodeKorea)):
+		return parseInternationalNumber(s, digits)
+	default:
+		return parseKoreanNumber(s, digits)
+	}
+}
+
+// phoneDigits returns the digits of the given phone number and whether it is
+// written in the international format.
+func phoneDigits(s string) (digits string, international bool, err error) {
+	number := strings.TrimSpace(s)
+	if strings.HasPrefix(number, "+") {
+		international = true
+		number = number[1:]
+	}
+
+	var b strings.Builder
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", false, fmt.Errorf("%w %q: unexpected character %q", ErrInvalidPhoneNumber, s, r)
+		}
+	}
+
+	if b.Len() == 0 {
+		return "", false, fmt.Errorf("%w %q: no digits", ErrInvalidPhoneNumber, s)
+	}
+	return b.String(), international, nil
+}
+
+func parseInternationalNumber(s, digits string) (PhoneNumber, error) {
+	if len(digits) > 15 {
+		return PhoneNumber{}, fmt.Errorf("%w %q: international numbers have at most 15 digits", ErrInvalidPhoneNumber, s)
+	}
+
+	for n := 1; n <= 3 && n < len(digits); n++ {
+		code := digits[:n]
+		if !callingCodes[code] {
+			continue
+		}
+
+		national := digits[n:]
+		if code == string(CountryCodeKorea) {
+			// The representative numbers have no trunk prefix.
+			if !strings.HasPrefix(national, "0") && !isRepresentativeNumber(national) {
+				national = "0" + national
+			}
+			return parseKoreanNumber(s, national)
+		}
+		if len(national) < 4 {
+			return PhoneNumber{}, fmt.Errorf("%w %q: the number is too short", ErrInvalidPhoneNumber, s)
+		}
+		return PhoneNumber{CountryCode: SMSCountryCode(code), Number: national}, nil
+	}
+
+	return PhoneNumber{}, fmt.Errorf("%w %q: unknown country code", ErrInvalidPhoneNumber, s)
+}
+
+// parseKoreanNumber parses a Korean number as dialed in Korea.
+func parseKoreanNumber(s, digits string) (PhoneNumber, error) {
+	if !isKoreanNumber(digits) {
+		return PhoneNumber{}, fmt.Errorf("%w %q: not a Korean phone number, international numbers must start with +", ErrInvalidPhoneNumber, s)
+	}
+	return PhoneNumber{CountryCode: CountryCodeKorea, Number: digits}, nil
+}
+
+func isKoreanNumber(digits string) bool {
+	n := len(digits)
+	switch {
+	case isRepresentativeNumber(digits):
+		return true
+	case strings.HasPrefix(digits, "02"):
+		// 서울
+		return n == 9 || n == 10
+	case len(digits) < 3:
+		return false
+	}
+
+	switch prefix := digits[:3]; prefix {
+	case "010":
+		return n == 11
+	case "011", "016", "017", "018", "019":
+		return n == 10 || n == 11
+	case "070":
+		return n == 11
+	case "080":
+		return n == 10 || n == 11
+	case "050":
+		return n == 11 || n == 12
+	case "031", "032", "033", "041", "042", "043", "044", "051", "052", "053", "054", "055", "061", "062", "063", "064":
+		return n == 10 || n == 11
+	default:
+		return false
+	}
+}
+
+// isRepresentativeNumber reports whether the given digits are a Korean
+// representative number (대표번호), e.g. 1588-1234.
+func isRepresentativeNumber(digits string) bool {
+	if len(digits) != 8 {
+		return false
+	}
+	return strings.HasPrefix(digits, "15") || strings.HasPrefix(digits, "16") || strings.HasPrefix(digits, "18")
+}
+
+// IsKorean reports whether the number is a Korean one.
+func (p PhoneNumber) IsKorean() bool {
+	return p.CountryCode == CountryCodeKorea
+}
+
+// E164 returns the number in the E.164 format, e.g. "+821012345678".
+func (p PhoneNumber) E164() string {
+	if p.IsKorean() {
+		return "+" + string(p.CountryCode) + strings.TrimPrefix(p.Number, "0")
+	}
+	return "+" + string(p.CountryCode) + p.Number
+}
+
+// String returns the number in the E.164 format.
+func (p PhoneNumber) String() string {
+	return p.E164()
+}
+
+// NormalizeSenderNumber returns the digits of the given sender number, which
+// must be a Korean number registered as a calling number in the SENS console.
+// It returns an error wrapping ErrInvalidPhoneNumber otherwise.
+func NormalizeSenderNumber(s string) (string, error) {
+	p, err := ParsePhoneNumber(s)
+	if err != nil {
+		return "", err
+	}
+	if !p.IsKorean() {
+		return "", fmt.Errorf("%w %q: sender numbers must be Korean numbers", ErrInvalidPhoneNumber, s)
+	}
+	return p.Number, nil
+}
+
+// GroupByCountry parses the From and the recipients of the request with
+// NormalizeSenderNumber and ParsePhoneNumber and returns one request per
+// country code of the recipients, with its CountryCode set and the numbers
+// normalized as expected by the API. The recipients written without country
+// code are Korean ones.
+// The requests are returned in order of first appearance of their country.
+func (req SendSMSRequest) GroupByCountry() ([]SendSMSRequest, error) {
+	from, err := NormalizeSenderNumber(req.From)
+	if err != nil {
+		return nil, fmt.Errorf("sender: %w", err)
+	}
+
+	var (
+		reqs    []SendSMSRequest
+		indexes = make(map[SMSCountryCode]int)
+	)
+	for i, msg := range req.Messages {
+		to, err := ParsePhoneNumber(msg.To)
+		if err != nil {
+			return nil, fmt.Errorf("recipient of message %d: %w", i, err)
+		}
+		msg.To = to.Number
+
+		idx, ok := indexes[to.CountryCode]
+		if !ok {
+			r := req
+			r.From = from
+			r.CountryCode = to.CountryCode
+			r.Messages = nil
+			idx = len(reqs)
+			indexes[to.CountryCode] = idx
+			reqs = append(reqs, r)
+		}
+		reqs[idx].Messages = append(reqs[idx].Messages, msg)
+	}
+
+	return reqs, nil
+}
+
+// Normalize parses the From and the recipients of the request, sets its
+// CountryCode and normalizes the numbers as expected by the API.
+// It returns an error wrapping ErrMixedCountryCodes if the recipients are in
+// several countries, see GroupByCountry.
+func (req SendSMSRequest) Normalize() (SendSMSRequest, error) {
+	reqs, err := req.GroupByCountry()
+	if err != nil {
+		return SendSMSRequest{}, err
+	}
+
+	switch len(reqs) {
+	case 0:
+		req.From, err = NormalizeSenderNumber(req.From)
+		return req, err
+	case 1:
+		return reqs[0], nil
+	default:
+		codes := make([]string, len(reqs))
+		for i, r := range reqs {
+			codes[i] = string(r.CountryCode)
+		}
+		return SendSMSRequest{}, fmt.Errorf("%w: %s", ErrMixedCountryCodes, strings.Join(codes, ", "))
+	}
+}
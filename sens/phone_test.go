@@ -0,0 +1,139 @@
+package sens_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   string
+		want     sens.PhoneNumber
+		wantE164 string
+		wantErr  error
+	}{
+		{name: "Korean mobile", number: "010-1234-5678", want: sens.PhoneNumber{CountryCode: "82", Number: "01012345678"}, wantE164: "+821012345678"},
+		{name: "Korean mobile without hyphens", number: "01012345678", want: sens.PhoneNumber{CountryCode: "82", Number: "01012345678"}, wantE164: "+821012345678"},
+		{name: "Korean mobile in E.164", number: "+82 10-1234-5678", want: sens.PhoneNumber{CountryCode: "82", Number: "01012345678"}, wantE164: "+821012345678"},
+		{name: "Korean mobile with trunk prefix", number: "+82 (0)10 1234 5678", want: sens.PhoneNumber{CountryCode: "82", Number: "01012345678"}, wantE164: "+821012345678"},
+		{name: "Korean mobile without plus", number: "821012345678", want: sens.PhoneNumber{CountryCode: "82", Number: "01012345678"}, wantE164: "+821012345678"},
+		{name: "Seoul landline", number: "02-123-4567", want: sens.PhoneNumber{CountryCode: "82", Number: "021234567"}, wantE164: "+8221234567"},
+		{name: "representative number", number: "1588-1234", want: sens.PhoneNumber{CountryCode: "82", Number: "15881234"}, wantE164: "+8215881234"},
+		{name: "representative number in E.164", number: "+82 1588-1234", want: sens.PhoneNumber{CountryCode: "82", Number: "15881234"}, wantE164: "+8215881234"},
+		{name: "16xx representative number in E.164", number: "+82 1661-1234", want: sens.PhoneNumber{CountryCode: "82", Number: "16611234"}, wantE164: "+8216611234"},
+		{name: "18xx representative number in E.164", number: "+82 1899-1234", want: sens.PhoneNumber{CountryCode: "82", Number: "18991234"}, wantE164: "+8218991234"},
+		{name: "old mobile in E.164", number: "+82 16-123-4567", want: sens.PhoneNumber{CountryCode: "82", Number: "0161234567"}, wantE164: "+82161234567"},
+		{name: "US number", number: "+1 (202) 555-0123", want: sens.PhoneNumber{CountryCode: "1", Number: "2025550123"}, wantE164: "+12025550123"},
+		{name: "Japanese number", number: "+81 90-1234-5678", want: sens.PhoneNumber{CountryCode: "81", Number: "9012345678"}, wantE164: "+819012345678"},
+		{name: "Vietnamese number", number: "+84.912.345.678", want: sens.PhoneNumber{CountryCode: "84", Number: "912345678"}, wantE164: "+84912345678"},
+		{name: "empty", number: "", wantErr: sens.ErrInvalidPhoneNumber},
+		{name: "letters", number: "010-CALL-NOW", wantErr: sens.ErrInvalidPhoneNumber},
+		{name: "too short Korean mobile", number: "010-123-456", wantErr: sens.ErrInvalidPhoneNumber},
+		{name: "foreign number without country code", number: "202-555-0123", wantErr: sens.ErrInvalidPhoneNumber},
+		{name: "unknown country code", number: "+999 1234 5678", wantErr: sens.ErrInvalidPhoneNumber},
+		{name: "too long", number: "+1 2025550123 45678", wantErr: sens.ErrInvalidPhoneNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sens.ParsePhoneNumber(tt.number)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Mismatch between the expected and the parsed number (-want +got):\n%s", diff)
+			}
+			if err == nil && got.E164() != tt.wantE164 {
+				t.Errorf("Expected E.164 number %q but got %q", tt.wantE164, got.E164())
+			}
+		})
+	}
+}
+
+func TestNormalizeSenderNumber(t *testing.T) {
+	got, err := sens.NormalizeSenderNumber("+82 2-1234-5678")
+	if err != nil {
+		t.Fatalf("NormalizeSenderNumber was given a valid number but failed: %v", err)
+	}
+	if got != "0212345678" {
+		t.Errorf("Expected %q but got %q", "0212345678", got)
+	}
+
+	_, err = sens.NormalizeSenderNumber("+1 202 555 0123")
+	if !errors.Is(err, sens.ErrInvalidPhoneNumber) {
+		t.Errorf("Expected error %v for a foreign sender but got: %v", sens.ErrInvalidPhoneNumber, err)
+	}
+}
+
+func TestSendSMSRequest_GroupByCountry(t *testing.T) {
+	req := sens.SendSMSRequest{
+		Type:    sens.SMSTypeSMS,
+		From:    "02-1234-5678",
+		Content: "test-content",
+		Messages: []sens.Message{
+			{To: "010-1234-5678"},
+			{To: "+1 202-555-0123", Content: "test-content-en"},
+			{To: "+82 10-8765-4321"},
+		},
+	}
+
+	got, err := req.GroupByCountry()
+	if err != nil {
+		t.Fatalf("GroupByCountry was given valid numbers but failed: %v", err)
+	}
+
+	want := []sens.SendSMSRequest{
+		{
+			Type:        sens.SMSTypeSMS,
+			CountryCode: "82",
+			From:        "0212345678",
+			Content:     "test-content",
+			Messages:    []sens.Message{{To: "01012345678"}, {To: "01087654321"}},
+		},
+		{
+			Type:        sens.SMSTypeSMS,
+			CountryCode: "1",
+			From:        "0212345678",
+			Content:     "test-content",
+			Messages:    []sens.Message{{To: "2025550123", Content: "test-content-en"}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Mismatch between the expected and the grouped requests (-want +got):\n%s", diff)
+	}
+
+	_, err = req.Normalize()
+	if !errors.Is(err, sens.ErrMixedCountryCodes) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMixedCountryCodes, err)
+	}
+}
+
+func TestSendSMSRequest_Normalize(t *testing.T) {
+	req := sens.SendSMSRequest{
+		From:     "1588-1234",
+		Messages: []sens.Message{{To: "82 10 1234 5678"}},
+	}
+
+	got, err := req.Normalize()
+	if err != nil {
+		t.Fatalf("Normalize was given valid numbers but failed: %v", err)
+	}
+
+	want := sens.SendSMSRequest{
+		CountryCode: sens.CountryCodeKorea,
+		From:        "15881234",
+		Messages:    []sens.Message{{To: "01012345678"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Mismatch between the expected and the normalized request (-want +got):\n%s", diff)
+	}
+
+	req.Messages[0].To = "010-12345"
+	_, err = req.Normalize()
+	if !errors.Is(err, sens.ErrInvalidPhoneNumber) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrInvalidPhoneNumber, err)
+	}
+}
@@ -0,0 +1,292 @@
+package sens
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+const (
+	EndpointPushAPI      = "/push/v2"
+	EndpointPushServices = EndpointPushAPI + "/services"
+)
+
+// DeviceType is the push service a device receives its notifications from.
+type DeviceType string
+
+const (
+	DeviceTypeFCM         DeviceType = "GCM"          // Firebase Cloud Messaging
+	DeviceTypeAPNs        DeviceType = "APNS"         // Apple Push Notification service
+	DeviceTypeAPNsSandbox DeviceType = "APNS_SANDBOX" // Apple Push Notification service (개발)
+)
+
+// PushMessageType is the type of a push message.
+type PushMessageType string
+
+const (
+	PushMessageTypeNotification PushMessageType = "NOTIF" // 일반 알림
+	PushMessageTypeAD           PushMessageType = "AD"    // 광고
+)
+
+// PushTargetType is the type of the recipients of a push message.
+type PushTargetType string
+
+const (
+	PushTargetAll     PushTargetType = "ALL"     // 전체 사용자
+	PushTargetUser    PushTargetType = "USER"    // 사용자 아이디
+	PushTargetChannel PushTargetType = "CHANNEL" // 채널
+)
+
+// PushClient is a REST client providing methods to register devices and send
+// them notifications through the Naver Cloud Platform SENS Push API.
+// It is configured as Client, but with the ID of a Push service.
+type PushClient struct {
+	ClientConfig
+}
+
+// NewPushClient returns a new Naver Cloud Platform Push API client given an
+// access key and a secret key to authenticate to the API and the ID of the
+// SENS Push service (project) to send the notifications from.
+// It uses the http.DefaultClient unless you provide your own.
+func NewPushClient(accessKey, secretKey, serviceID string, httpClient *http.Client) (*PushClient, error) {
+	cfg, err := newClientConfig(accessKey, secretKey, serviceID, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PushClient{ClientConfig: cfg}, nil
+}
+
+// WithServiceID returns a copy of the client sending its requests to the
+// SENS Push service identified by the given service ID.
+func (pc *PushClient) WithServiceID(serviceID string) *PushClient {
+	c := *pc
+	c.ServiceID = serviceID
+	return &c
+}
+
+// RegisterDeviceRequest represents the REST request to register the device
+// token of a user.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-pushv2
+type RegisterDeviceRequest struct {
+	UserID                  string     `json:"userId"`                  // 사용자 아이디 - 필수
+	ChannelName             string     `json:"channelName,omitempty"`   // 채널명 - 선택
+	DeviceType              DeviceType `json:"deviceType"`              // 디바이스 타입 (GCM | APNS | APNS_SANDBOX) - 필수
+	DeviceToken             string     `json:"deviceToken"`             // 디바이스 토큰 - 필수
+	IsNotificationAgreement bool       `json:"isNotificationAgreement"` // 알림 수신 동의 여부 - 필수
+	IsAdAgreement           bool       `json:"isAdAgreement"`           // 광고 수신 동의 여부 - 필수
+	IsNightAdAgreement      bool       `json:"isNightAdAgreement"`      // 야간 광고 수신 동의 여부 - 필수
+}
+
+// PushDevice is a device registered for a user.
+type PushDevice struct {
+	DeviceType  DeviceType `json:"deviceType"`
+	DeviceToken string     `json:"deviceToken"`
+	CreateTime  Time       `json:"createTime"`
+	UpdateTime  Time       `json:"updateTime"`
+}
+
+// PushUser represents a user registered in a SENS Push service with their
+// devices.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-pushv2
+type PushUser struct {
+	UserID                  string       `json:"userId"`
+	ChannelName             string       `json:"channelName"`
+	IsNotificationAgreement bool         `json:"isNotificationAgreement"`
+	IsAdAgreement           bool         `json:"isAdAgreement"`
+	IsNightAdAgreement      bool         `json:"isNightAdAgreement"`
+	Devices                 []PushDevice `json:"devices"`
+}
+
+// PushTarget describes the recipients of a push message.
+type PushTarget struct {
+	Type       PushTargetType `json:"type"`                 // 대상 타입 (ALL | USER | CHANNEL) - 필수
+	DeviceType DeviceType     `json:"deviceType,omitempty"` // 디바이스 타입 - 선택
+	To         []string       `json:"to,omitempty"`         // 사용자 아이디 또는 채널명(USER, CHANNEL 필수) - 선택
+	Country    []string       `json:"country,omitempty"`    // 국가 코드(ISO 3166-1 alpha-2) - 선택
+}
+
+// PushI18nContent is the content of a push message in a given language.
+type PushI18nContent struct {
+	Content string `json:"content"`
+}
+
+// PushPayload is the content of a push message for a platform.
+type PushPayload struct {
+	Content string `json:"content,omitempty"` // 메시지 내용 - 선택
+	// I18n holds the content of the message per language, e.g. "ko" or "en".
+	I18n map[string]PushI18nContent `json:"i18n,omitempty"`
+	// Option holds the platform specific fields of the notification, e.g.
+	// "sound" or "badge" for APNs and "notification" for FCM.
+	Option map[string]any `json:"option,omitempty"`
+	// Custom holds the custom fields handed over to the app.
+	Custom map[string]any `json:"custom,omitempty"`
+}
+
+// PushMessage holds the payloads of a push message. The Default payload is
+// sent to the platforms having no payload of their own.
+type PushMessage struct {
+	Default PushPayload  `json:"default"`        // 기본 메시지 - 필수
+	FCM     *PushPayload `json:"gcm,omitempty"`  // FCM 메시지 - 선택
+	APNs    *PushPayload `json:"apns,omitempty"` // APNs 메시지 - 선택
+}
+
+// SendPushRequest represents the REST request to send a push message.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-pushv2
+type SendPushRequest struct {
+	MessageType     PushMessageType `json:"messageType"`               // 메시지 타입 (NOTIF | AD) - 필수
+	Target          PushTarget      `json:"target"`                    // 대상 정보 - 필수
+	Message         PushMessage     `json:"message"`                   // 메시지 정보 - 필수
+	ReserveTime     string          `json:"reserveTime,omitempty"`     // 예약 시간("yyyy-MM-dd HH:mm", ReserveAt 으로 설정 가능) - 선택
+	ReserveTimeZone string          `json:"reserveTimezone,omitempty"` // 예약 시간 타임존("Asia/Seoul") - 선택
+}
+
+// SendPushResponse represents the response sent by the SENS Push API after a
+// request to send a push message.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-pushv2
+type SendPushResponse struct {
+	RequestID string `json:"requestId"`
+}
+
+// PushMessageResult represents the result of a push message.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-pushv2
+type PushMessageResult struct {
+	RequestID       string          `json:"requestId"`
+	MessageType     PushMessageType `json:"messageType"`
+	Target          PushTarget      `json:"target"`
+	Message         PushMessage     `json:"message"`
+	ReserveTime     string          `json:"reserveTime,omitempty"`
+	ReserveTimeZone string          `json:"reserveTimezone,omitempty"`
+	Status          string          `json:"status"`         // 발송 상태
+	CompletedCount  int             `json:"completedCount"` // 발송 성공 수
+	FailedCount     int             `json:"failedCount"`    // 발송 실패 수
+	CompleteTime    Time            `json:"completeTime"`   // 발송 완료 시간
+}
+
+// PushUsersEndpoint returns the path of the users endpoint of the SENS Push
+// service identified by the given service ID.
+func PushUsersEndpoint(serviceID string) string {
+	return path.Join(EndpointPushServices, serviceID, "users")
+}
+
+// PushUserEndpoint returns the path of the endpoint of the user identified by
+// the given user ID in the SENS Push service identified by the given service
+// ID.
+func PushUserEndpoint(serviceID, userID string) string {
+	return path.Join(PushUsersEndpoint(serviceID), url.PathEscape(userID))
+}
+
+// PushMessagesEndpoint returns the path of the messages endpoint of the SENS
+// Push service identified by the given service ID.
+func PushMessagesEndpoint(serviceID string) string {
+	return path.Join(EndpointPushServices, serviceID, "messages")
+}
+
+// PushMessageEndpoint returns the path of the endpoint of the push message
+// sent by the request identified by the given request ID in the SENS Push
+// service identified by the given service ID.
+func PushMessageEndpoint(serviceID, requestID string) string {
+	return path.Join(PushMessagesEndpoint(serviceID), url.PathEscape(requestID))
+}
+
+// RegisterDevice registers the device token of a user, creating the user if
+// needed.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-pushv2
+func (pc *PushClient) RegisterDevice(ctx context.Context, req RegisterDeviceRequest) error {
+	if pc.ServiceID == "" {
+		return ErrMissingServiceID
+	}
+
+	return pc.api().doJSON(ctx, http.MethodPost, PushUsersEndpoint(pc.ServiceID), nil, req, nil)
+}
+
+// GetUser returns the user identified by the given user ID with their
+// registered devices.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-pushv2
+func (pc *PushClient) GetUser(ctx context.Context, userID string) (PushUser, error) {
+	if pc.ServiceID == "" {
+		return PushUser{}, ErrMissingServiceID
+	}
+	err := checkID("user ID", userID)
+	if err != nil {
+		return PushUser{}, err
+	}
+
+	var resp PushUser
+	err = pc.api().doJSON(ctx, http.MethodGet, PushUserEndpoint(pc.ServiceID, userID), nil, nil, &resp)
+	if err != nil {
+		return PushUser{}, err
+	}
+
+	return resp, nil
+}
+
+// DeleteUser deletes the user identified by the given user ID along with all
+// their registered devices.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-pushv2
+func (pc *PushClient) DeleteUser(ctx context.Context, userID string) error {
+	if pc.ServiceID == "" {
+		return ErrMissingServiceID
+	}
+	err := checkID("user ID", userID)
+	if err != nil {
+		return err
+	}
+
+	return pc.api().doJSON(ctx, http.MethodDelete, PushUserEndpoint(pc.ServiceID, userID), nil, nil, nil)
+}
+
+// SendPush sends a request to send a push message to the Push API using the
+// given request parameters. The message is sent at ReserveTime if set.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-pushv2
+func (pc *PushClient) SendPush(ctx context.Context, req SendPushRequest) (SendPushResponse, error) {
+	if pc.ServiceID == "" {
+		return SendPushResponse{}, ErrMissingServiceID
+	}
+
+	err := checkReserveTime(pc.Clock, req.ReserveTime, req.ReserveTimeZone)
+	if err != nil {
+		return SendPushResponse{}, err
+	}
+
+	var resp SendPushResponse
+	err = pc.api().doJSON(ctx, http.MethodPost, PushMessagesEndpoint(pc.ServiceID), nil, req, &resp)
+	if err != nil {
+		return SendPushResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// GetPushMessageResult returns the result of the push message sent by the
+// request identified by the given request ID, as returned in
+// SendPushResponse.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-pushv2
+func (pc *PushClient) GetPushMessageResult(ctx context.Context, requestID string) (PushMessageResult, error) {
+	if pc.ServiceID == "" {
+		return PushMessageResult{}, ErrMissingServiceID
+	}
+	err := checkID("request ID", requestID)
+	if err != nil {
+		return PushMessageResult{}, err
+	}
+
+	var resp PushMessageResult
+	err = pc.api().doJSON(ctx, http.MethodGet, PushMessageEndpoint(pc.ServiceID, requestID), nil, nil, &resp)
+	if err != nil {
+		return PushMessageResult{}, err
+	}
+
+	return resp, nil
+}
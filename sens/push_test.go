@@ -0,0 +1,197 @@
+package sens_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/connectfit-team/naverapi/internal/testhelper"
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPushClient_RegisterDevice(t *testing.T) {
+	client, mux, teardown := setupTestPushClient()
+	defer teardown()
+
+	req := sens.RegisterDeviceRequest{
+		UserID:                  "test-user-id",
+		DeviceType:              sens.DeviceTypeAPNs,
+		DeviceToken:             "test-device-token",
+		IsNotificationAgreement: true,
+	}
+
+	mux.HandleFunc(sens.PushUsersEndpoint(testPushServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodPost)
+		testhelper.TestRequestHeader(t, r, "Content-Type", "application/json")
+		testhelper.TestRequestBody(t, r, `{"userId":"test-user-id","deviceType":"APNS","deviceToken":"test-device-token","isNotificationAgreement":true,"isAdAgreement":false,"isNightAdAgreement":false}`)
+
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := client.RegisterDevice(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Register device request was given a valid request but failed: %v", err)
+	}
+}
+
+func TestPushClient_GetUser(t *testing.T) {
+	client, mux, teardown := setupTestPushClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.PushUserEndpoint(testPushServiceID, "test-user-id"), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, `{"userId":"test-user-id","channelName":"default","isNotificationAgreement":true,"devices":[{"deviceType":"GCM","deviceToken":"test-device-token"}]}`)
+	})
+
+	got, err := client.GetUser(context.Background(), "test-user-id")
+	if err != nil {
+		t.Fatalf("Get user request was given a valid request but failed: %v", err)
+	}
+
+	want := sens.PushUser{
+		UserID:                  "test-user-id",
+		ChannelName:             "default",
+		IsNotificationAgreement: true,
+		Devices: []sens.PushDevice{
+			{DeviceType: sens.DeviceTypeFCM, DeviceToken: "test-device-token"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Mismatch between the expected and the returned user (-want +got):\n%s", diff)
+	}
+}
+
+func TestPushClient_DeleteUser(t *testing.T) {
+	client, mux, teardown := setupTestPushClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.PushUserEndpoint(testPushServiceID, "test-user-id"), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodDelete)
+
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errorCode":"404","message":"User not found"}`)
+	})
+
+	err := client.DeleteUser(context.Background(), "test-user-id")
+	var apiErr *naverapi.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected a 404 API error but got: %v", err)
+	}
+}
+
+func TestPushClient_SendPush(t *testing.T) {
+	client, mux, teardown := setupTestPushClient()
+	defer teardown()
+
+	req := sens.SendPushRequest{
+		MessageType: sens.PushMessageTypeNotification,
+		Target: sens.PushTarget{
+			Type: sens.PushTargetUser,
+			To:   []string{"test-user-id"},
+		},
+		Message: sens.PushMessage{
+			Default: sens.PushPayload{
+				Content: "주문이 접수되었습니다.",
+				I18n: map[string]sens.PushI18nContent{
+					"en": {Content: "Your order has been received."},
+				},
+			},
+			APNs: &sens.PushPayload{
+				Content: "주문이 접수되었습니다.",
+				Option:  map[string]any{"badge": float64(1), "sound": "default"},
+			},
+		},
+		ReserveTime:     "1997-03-01 10:15",
+		ReserveTimeZone: "Asia/Seoul",
+	}
+
+	mux.HandleFunc(sens.PushMessagesEndpoint(testPushServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodPost)
+
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Timestamp", "856915200000")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Iam-Access-Key", "test-access-key")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "s/FHyiplqvPo+lSwdyfRFekNLwrlG6x9M9UIoOEaBqE=")
+
+		var got sens.SendPushRequest
+		err := json.NewDecoder(r.Body).Decode(&got)
+		if err != nil {
+			t.Errorf("could not decode the request body: %v", err)
+		}
+		if diff := cmp.Diff(req, got); diff != "" {
+			t.Errorf("Mismatch between the expected and the sent request (-want +got):\n%s", diff)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id"}`)
+	})
+
+	got, err := client.SendPush(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Send push request was given a valid request but failed: %v", err)
+	}
+	if got.RequestID != "test-request-id" {
+		t.Errorf("Expected request ID %q but got %q", "test-request-id", got.RequestID)
+	}
+}
+
+func TestPushClient_GetPushMessageResult(t *testing.T) {
+	client, mux, teardown := setupTestPushClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.PushMessageEndpoint(testPushServiceID, "test-request-id"), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, `{"requestId":"test-request-id","messageType":"NOTIF","target":{"type":"ALL"},"message":{"default":{"content":"test-content"}},"status":"COMPLETED","completedCount":41,"failedCount":1}`)
+	})
+
+	got, err := client.GetPushMessageResult(context.Background(), "test-request-id")
+	if err != nil {
+		t.Fatalf("Get push message result request was given a valid request but failed: %v", err)
+	}
+
+	want := sens.PushMessageResult{
+		RequestID:      "test-request-id",
+		MessageType:    sens.PushMessageTypeNotification,
+		Target:         sens.PushTarget{Type: sens.PushTargetAll},
+		Message:        sens.PushMessage{Default: sens.PushPayload{Content: "test-content"}},
+		Status:         "COMPLETED",
+		CompletedCount: 41,
+		FailedCount:    1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Mismatch between the expected and the returned result (-want +got):\n%s", diff)
+	}
+}
+
+func TestPushClient_ShouldFailIfNoID(t *testing.T) {
+	client, _, teardown := setupTestPushClient()
+	defer teardown()
+
+	ctx := context.Background()
+	_, err := client.GetUser(ctx, "")
+	if !errors.Is(err, sens.ErrMissingID) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMissingID, err)
+	}
+	err = client.DeleteUser(ctx, "")
+	if !errors.Is(err, sens.ErrMissingID) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMissingID, err)
+	}
+	_, err = client.GetPushMessageResult(ctx, "")
+	if !errors.Is(err, sens.ErrMissingID) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMissingID, err)
+	}
+}
+
+func TestPushMessageEndpoint_ShouldEscapeTheRequestID(t *testing.T) {
+	got := sens.PushMessageEndpoint(testPushServiceID, "test/request")
+	want := sens.PushMessagesEndpoint(testPushServiceID) + "/test%2Frequest"
+	if got != want {
+		t.Errorf("Expected the path %q but got %q", want, got)
+	}
+}
@@ -0,0 +1,150 @@
+package sens
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// ReserveStatus is the status of a reserved send request.
+type ReserveStatus string
+
+const (
+	ReserveStatusReady      ReserveStatus = "READY"      // 예약 대기
+	ReserveStatusProcessing ReserveStatus = "PROCESSING" // 발송 중
+	ReserveStatusCanceled   ReserveStatus = "CANCELED"   // 예약 취소
+	ReserveStatusFail       ReserveStatus = "FAIL"       // 발송 실패
+	ReserveStatusDone       ReserveStatus = "DONE"       // 발송 완료
+	ReserveStatusStale      ReserveStatus = "STALE"      // 만료
+)
+
+// ReservationStatus represents the response sent by the SENS SMS API after a
+// request to get the status of a reserved send request.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+type ReservationStatus struct {
+	ReserveID       string        `json:"reserveId"`
+	ReserveTimeZone string        `json:"reserveTimeZone"`
+	ReserveTime     string        `json:"reserveTime"`
+	ReserveStatus   ReserveStatus `json:"reserveStatus"`
+}
+
+// ScheduledMessageStatus represents the response sent by the SENS SMS API
+// after a request to get the status of a message sent by a schedule.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+type ScheduledMessageStatus struct {
+	ScheduleCode    string        `json:"scheduleCode"`
+	MessageID       string        `json:"messageId"`
+	ReserveTimeZone string        `json:"reserveTimeZone"`
+	ReserveTime     string        `json:"reserveTime"`
+	ReserveStatus   ReserveStatus `json:"reserveStatus"`
+}
+
+// ReservationEndpoint returns the path of the endpoint of the reservation
+// identified by the given reserve ID in the SENS SMS service identified by the
+// given service ID.
+func ReservationEndpoint(serviceID, reserveID string) string {
+	return path.Join(EndpointSMSServices, serviceID, "reservations", url.PathEscape(reserveID))
+}
+
+// ScheduledMessageEndpoint returns the path of the endpoint of the message
+// identified by the given message ID sent by the schedule identified by the
+// given schedule code in the SENS SMS service identified by the given service
+// ID.
+func ScheduledMessageEndpoint(serviceID, scheduleCode, messageID string) string {
+	return path.Join(EndpointSMSServices, serviceID, "schedules", url.PathEscape(scheduleCode), "messages", url.PathEscape(messageID))
+}
+
+// GetReservationStatus returns the status of the reserved send request
+// identified by the given reserve ID, which is the request ID returned in
+// the SendSMSResponse of a request with a ReserveTime.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+func (ss *Client) GetReservationStatus(ctx context.Context, reserveID string) (ReservationStatus, error) {
+	if ss.ServiceID == "" {
+		return ReservationStatus{}, ErrMissingServiceID
+	}
+	err := checkID("reserve ID", reserveID)
+	if err != nil {
+		return ReservationStatus{}, err
+	}
+
+	endpoint := path.Join(ReservationEndpoint(ss.ServiceID, reserveID), "reserve-status")
+
+	var resp ReservationStatus
+	err = ss.api().doJSON(ctx, http.MethodGet, endpoint, nil, nil, &resp)
+	if err != nil {
+		return ReservationStatus{}, err
+	}
+
+	return resp, nil
+}
+
+// CancelReservation cancels the reserved send request identified by the
+// given reserve ID. Only the requests whose status is still READY can be
+// canceled.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+func (ss *Client) CancelReservation(ctx context.Context, reserveID string) error {
+	if ss.ServiceID == "" {
+		return ErrMissingServiceID
+	}
+	err := checkID("reserve ID", reserveID)
+	if err != nil {
+		return err
+	}
+
+	return ss.api().doJSON(ctx, http.MethodDelete, ReservationEndpoint(ss.ServiceID, reserveID), nil, nil, nil)
+}
+
+// CancelScheduledMessage cancels the message identified by the given message
+// ID, sent by the schedule identified by the given schedule code (the
+// ScheduleCode of the SendSMSRequest).
+// The status of a scheduled message can be looked up with
+// GetScheduledMessageStatus.
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+func (ss *Client) CancelScheduledMessage(ctx context.Context, scheduleCode, messageID string) error {
+	if ss.ServiceID == "" {
+		return ErrMissingServiceID
+	}
+	err := checkScheduledMessageIDs(scheduleCode, messageID)
+	if err != nil {
+		return err
+	}
+
+	return ss.api().doJSON(ctx, http.MethodDelete, ScheduledMessageEndpoint(ss.ServiceID, scheduleCode, messageID), nil, nil, nil)
+}
+
+// GetScheduledMessageStatus returns the status of the message identified by
+// the given message ID, sent by the schedule identified by the given schedule
+// code (the ScheduleCode of the SendSMSRequest).
+//
+// See https://api.ncloud-docs.com/docs/en/ai-application-service-sens-smsv2
+func (ss *Client) GetScheduledMessageStatus(ctx context.Context, scheduleCode, messageID string) (ScheduledMessageStatus, error) {
+	if ss.ServiceID == "" {
+		return ScheduledMessageStatus{}, ErrMissingServiceID
+	}
+	err := checkScheduledMessageIDs(scheduleCode, messageID)
+	if err != nil {
+		return ScheduledMessageStatus{}, err
+	}
+
+	var resp ScheduledMessageStatus
+	err = ss.api().doJSON(ctx, http.MethodGet, ScheduledMessageEndpoint(ss.ServiceID, scheduleCode, messageID), nil, nil, &resp)
+	if err != nil {
+		return ScheduledMessageStatus{}, err
+	}
+
+	return resp, nil
+}
+
+func checkScheduledMessageIDs(scheduleCode, messageID string) error {
+	err := checkID("schedule code", scheduleCode)
+	if err != nil {
+		return err
+	}
+	return checkID("message ID", messageID)
+}
@@ -0,0 +1,145 @@
+package sens_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/connectfit-team/naverapi/internal/testhelper"
+	"github.com/connectfit-team/naverapi/sens"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSENSClient_GetReservationStatus(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(path.Join(sens.ReservationEndpoint(testServiceID, "test-reserve-id"), "reserve-status"), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "oplVuzhvakgKa6pS2IKngcaRxEEHORUPlpyAhCWxyo0=")
+
+		fmt.Fprint(w, `{"reserveId":"test-reserve-id","reserveTimeZone":"Asia/Seoul","reserveTime":"2023-03-02 10:15","reserveStatus":"READY"}`)
+	})
+
+	got, err := client.GetReservationStatus(context.Background(), "test-reserve-id")
+	if err != nil {
+		t.Fatalf("Get reservation status request was given a valid request but failed: %v", err)
+	}
+
+	want := sens.ReservationStatus{
+		ReserveID:       "test-reserve-id",
+		ReserveTimeZone: "Asia/Seoul",
+		ReserveTime:     "2023-03-02 10:15",
+		ReserveStatus:   sens.ReserveStatusReady,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Response differ from the expected one: %s", diff)
+	}
+}
+
+func TestSENSClient_CancelReservation(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.ReservationEndpoint(testServiceID, "test-reserve-id"), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodDelete)
+
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "LO01JmJTsYiupEmfAbx90ijCg6MtY0JTZwWKJpmMGZo=")
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.CancelReservation(context.Background(), "test-reserve-id")
+	if err != nil {
+		t.Fatalf("Cancel reservation request was given a valid request but failed: %v", err)
+	}
+}
+
+func TestSENSClient_CancelScheduledMessage(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.ScheduledMessageEndpoint(testServiceID, "test-schedule-code", "test-message-id"), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodDelete)
+
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"errorCode":"400","message":"the schedule has already been sent"}}`)
+	})
+
+	err := client.CancelScheduledMessage(context.Background(), "test-schedule-code", "test-message-id")
+	if naverapi.IsRetryable(err) || err == nil {
+		t.Fatalf("Cancel scheduled message request should fail with a non retryable error but got: %v", err)
+	}
+}
+
+func TestSENSClient_GetScheduledMessageStatus(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.ScheduledMessageEndpoint(testServiceID, "test-schedule-code", "test-message-id"), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, `{"scheduleCode":"test-schedule-code","messageId":"test-message-id","reserveTimeZone":"Asia/Seoul","reserveTime":"2023-03-02 10:15","reserveStatus":"READY"}`)
+	})
+
+	got, err := client.GetScheduledMessageStatus(context.Background(), "test-schedule-code", "test-message-id")
+	if err != nil {
+		t.Fatalf("Get scheduled message status request was given a valid request but failed: %v", err)
+	}
+
+	want := sens.ScheduledMessageStatus{
+		ScheduleCode:    "test-schedule-code",
+		MessageID:       "test-message-id",
+		ReserveTimeZone: "Asia/Seoul",
+		ReserveTime:     "2023-03-02 10:15",
+		ReserveStatus:   sens.ReserveStatusReady,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Response differ from the expected one: %s", diff)
+	}
+
+	reservedAt, err := got.ReservedAt()
+	if err != nil {
+		t.Fatalf("ReservedAt failed: %v", err)
+	}
+	if wantTime := time.Date(2023, 3, 2, 10, 15, 0, 0, sens.KST); !reservedAt.Equal(wantTime) {
+		t.Errorf("Expected reserve time %v but got %v", wantTime, reservedAt)
+	}
+}
+
+func TestSENSClient_Reservations_ShouldFailIfNoID(t *testing.T) {
+	client, _, teardown := setupTestSENSClient()
+	defer teardown()
+
+	ctx := context.Background()
+	_, err := client.GetReservationStatus(ctx, "")
+	if !errors.Is(err, sens.ErrMissingID) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMissingID, err)
+	}
+	err = client.CancelReservation(ctx, "")
+	if !errors.Is(err, sens.ErrMissingID) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMissingID, err)
+	}
+	err = client.CancelScheduledMessage(ctx, "", "test-message-id")
+	if !errors.Is(err, sens.ErrMissingID) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMissingID, err)
+	}
+	_, err = client.GetScheduledMessageStatus(ctx, "test-schedule-code", "")
+	if !errors.Is(err, sens.ErrMissingID) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrMissingID, err)
+	}
+}
+
+func TestScheduledMessageEndpoint_ShouldEscapeTheIDs(t *testing.T) {
+	got := sens.ScheduledMessageEndpoint(testServiceID, "test/schedule", "test?message")
+	want := sens.EndpointSMSServices + "/" + testServiceID + "/schedules/test%2Fschedule/messages/test%3Fmessage"
+	if got != want {
+		t.Errorf("Expected the path %q but got %q", want, got)
+	}
+}
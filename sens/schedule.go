@@ -0,0 +1,157 @@
+package sens
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// ReserveTimeLayout is the layout of the reserve times sent to the SENS
+	// API.
+	ReserveTimeLayout = "2006-01-02 15:04"
+	// DefaultReserveTimeZone is the time zone of the reserve times sent
+	// without time zone.
+	DefaultReserveTimeZone = "Asia/Seoul"
+	// MaxReserveAhead is how far in the future a message can be reserved.
+	MaxReserveAhead = 180 * 24 * time.Hour
+)
+
+var (
+	// ErrInvalidReserveTime is returned when a reserve time or its time zone
+	// is malformed.
+	ErrInvalidReserveTime = errors.New("invalid reserve time")
+	// ErrReserveTimeInPast is returned when a message is reserved for a time
+	// which has already passed.
+	ErrReserveTimeInPast = errors.New("the reserve time is in the past")
+	// ErrReserveTimeTooFar is returned when a message is reserved for a time
+	// further than MaxReserveAhead.
+	ErrReserveTimeTooFar = errors.New("the reserve time is too far in the future")
+)
+
+// FormatReserveTime returns the reserve time and reserve time zone to send to
+// the SENS API for the given time, expressed in its location if it has an
+// IANA name known by time.LoadLocation. Otherwise, e.g. for time.Local or a
+// fixed zone, the time is converted to KST and sent as Asia/Seoul.
+// The returned error is always nil and kept for compatibility.
+func FormatReserveTime(t time.Time) (reserveTime, reserveTimeZone string, err error) {
+	name := t.Location().String()
+	if _, loadErr := time.LoadLocation(name); t.Location() != KST && name != "" && name != "Local" && loadErr == nil {
+		return t.Format(ReserveTimeLayout), name, nil
+	}
+	return t.In(KST).Format(ReserveTimeLayout), DefaultReserveTimeZone, nil
+}
+
+// ParseReserveTime parses a reserve time and its time zone as sent to or
+// returned by the SENS API. The time zone defaults to DefaultReserveTimeZone.
+func ParseReserveTime(reserveTime, reserveTimeZone string) (time.Time, error) {
+	loc, err := reserveLocation(reserveTimeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for _, layout := range []string{ReserveTimeLayout, DateTimeLayout} {
+		t, err := time.ParseInLocation(layout, reserveTime, loc)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q does not match %q", ErrInvalidReserveTime, reserveTime, ReserveTimeLayout)
+}
+
+func reserveLocation(name string) (*time.Location, error) {
+	if name == "" {
+		name = DefaultReserveTimeZone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		if name == DefaultReserveTimeZone {
+			// The time zone database may be missing.
+			return KST, nil
+		}
+		return nil, fmt.Errorf("%w: unknown time zone %q: %v", ErrInvalidReserveTime, name, err)
+	}
+	return loc, nil
+}
+
+// checkReserveTime checks that the given reserve time, if any, is between now
+// and MaxReserveAhead, according to the given clock.
+func checkReserveTime(clock Clock, reserveTime, reserveTimeZone string) error {
+	if reserveTime == "" {
+		return nil
+	}
+
+	t, err := ParseReserveTime(reserveTime, reserveTimeZone)
+	if err != nil {
+		return err
+	}
+
+	now := now(clock)
+	switch {
+	case t.Before(now.Truncate(time.Minute)):
+		return fmt.Errorf("%w: %s is before %s", ErrReserveTimeInPast, t, now)
+	case t.After(now.Add(MaxReserveAhead)):
+		return fmt.Errorf("%w: %s is more than %v after %s", ErrReserveTimeTooFar, t, MaxReserveAhead, now)
+	}
+	return nil
+}
+
+// ReserveAt returns a copy of the request reserved for the given time, see
+// FormatReserveTime.
+func (req SendSMSRequest) ReserveAt(t time.Time) (SendSMSRequest, error) {
+	var err error
+	req.ReserveTime, req.ReserveTimeZone, err = FormatReserveTime(t)
+	return req, err
+}
+
+// ReservedAt returns the time the request is reserved for, the zero time if
+// it is not reserved.
+func (req SendSMSRequest) ReservedAt() (time.Time, error) {
+	if req.ReserveTime == "" {
+		return time.Time{}, nil
+	}
+	return ParseReserveTime(req.ReserveTime, req.ReserveTimeZone)
+}
+
+// ReserveAt returns a copy of the request reserved for the given time, see
+// FormatReserveTime.
+func (req SendAlimTalkRequest) ReserveAt(t time.Time) (SendAlimTalkRequest, error) {
+	var err error
+	req.ReserveTime, req.ReserveTimeZone, err = FormatReserveTime(t)
+	return req, err
+}
+
+// ReserveAt returns a copy of the request reserved for the given time, see
+// FormatReserveTime.
+func (req SendFriendTalkRequest) ReserveAt(t time.Time) (SendFriendTalkRequest, error) {
+	var err error
+	req.ReserveTime, req.ReserveTimeZone, err = FormatReserveTime(t)
+	return req, err
+}
+
+// ReserveAt returns a copy of the request reserved for the given time, see
+// FormatReserveTime.
+func (req SendPushRequest) ReserveAt(t time.Time) (SendPushRequest, error) {
+	var err error
+	req.ReserveTime, req.ReserveTimeZone, err = FormatReserveTime(t)
+	return req, err
+}
+
+// ReservedAt returns the time the request is reserved for.
+func (rs ReservationStatus) ReservedAt() (time.Time, error) {
+	return ParseReserveTime(rs.ReserveTime, rs.ReserveTimeZone)
+}
+
+// ReservedAt returns the time the scheduled message is reserved for.
+func (ms ScheduledMessageStatus) ReservedAt() (time.Time, error) {
+	return ParseReserveTime(ms.ReserveTime, ms.ReserveTimeZone)
+}
+
+// ReservedAt returns the time the message was reserved for, the zero time if
+// it was not reserved.
+func (mr PushMessageResult) ReservedAt() (time.Time, error) {
+	if mr.ReserveTime == "" {
+		return time.Time{}, nil
+	}
+	return ParseReserveTime(mr.ReserveTime, mr.ReserveTimeZone)
+}
@@ -0,0 +1,157 @@
+package sens_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi/internal/testhelper"
+	"github.com/connectfit-team/naverapi/sens"
+)
+
+func TestFormatReserveTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("the time zone database is not available: %v", err)
+	}
+	kstOffset, err := time.Parse(time.RFC3339, "2023-03-02T10:15:00+09:00")
+	if err != nil {
+		t.Fatalf("could not parse the test time: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		t            time.Time
+		wantTime     string
+		wantTimeZone string
+	}{
+		{name: "KST", t: time.Date(2023, 3, 2, 10, 15, 30, 0, sens.KST), wantTime: "2023-03-02 10:15", wantTimeZone: "Asia/Seoul"},
+		{name: "UTC", t: time.Date(2023, 3, 2, 1, 15, 0, 0, time.UTC), wantTime: "2023-03-02 01:15", wantTimeZone: "UTC"},
+		{name: "named location", t: time.Date(2023, 3, 1, 20, 15, 0, 0, newYork), wantTime: "2023-03-01 20:15", wantTimeZone: "America/New_York"},
+		{name: "KST fixed zone", t: time.Date(2023, 3, 2, 10, 15, 0, 0, time.FixedZone("KST", 9*60*60)), wantTime: "2023-03-02 10:15", wantTimeZone: "Asia/Seoul"},
+		{name: "parsed +09:00 offset", t: kstOffset, wantTime: "2023-03-02 10:15", wantTimeZone: "Asia/Seoul"},
+		{name: "unknown zone name", t: time.Date(2023, 3, 2, 10, 15, 0, 0, time.FixedZone("Mars/Olympus", 3600)), wantTime: "2023-03-02 18:15", wantTimeZone: "Asia/Seoul"},
+		{name: "unnamed fixed zone", t: time.Date(2023, 3, 2, 10, 15, 0, 0, time.FixedZone("", 3600)), wantTime: "2023-03-02 18:15", wantTimeZone: "Asia/Seoul"},
+		{name: "unnamed local zone", t: time.Date(2023, 3, 2, 1, 15, 0, 0, time.FixedZone("Local", 0)), wantTime: "2023-03-02 10:15", wantTimeZone: "Asia/Seoul"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTime, gotTimeZone, err := sens.FormatReserveTime(tt.t)
+			if err != nil {
+				t.Fatalf("FormatReserveTime was given a valid time but failed: %v", err)
+			}
+			if gotTime != tt.wantTime || gotTimeZone != tt.wantTimeZone {
+				t.Errorf("Expected %q %q but got %q %q", tt.wantTime, tt.wantTimeZone, gotTime, gotTimeZone)
+			}
+		})
+	}
+}
+
+func TestSendSMSRequest_ReserveAt(t *testing.T) {
+	want := time.Date(2023, 3, 2, 10, 15, 0, 0, sens.KST)
+
+	req, err := sens.SendSMSRequest{}.ReserveAt(want)
+	if err != nil {
+		t.Fatalf("ReserveAt was given a valid time but failed: %v", err)
+	}
+	if req.ReserveTime != "2023-03-02 10:15" || req.ReserveTimeZone != "Asia/Seoul" {
+		t.Errorf("Unexpected reserve time %q %q", req.ReserveTime, req.ReserveTimeZone)
+	}
+
+	got, err := req.ReservedAt()
+	if err != nil {
+		t.Fatalf("ReservedAt failed: %v", err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("Expected reserve time %v but got %v", want, got)
+	}
+}
+
+func TestParseReserveTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     string
+		timeZone string
+		want     time.Time
+		wantErr  error
+	}{
+		{name: "request layout", time: "2023-03-02 10:15", timeZone: "Asia/Seoul", want: time.Date(2023, 3, 2, 1, 15, 0, 0, time.UTC)},
+		{name: "response layout", time: "2023-03-02 10:15:00", timeZone: "Asia/Seoul", want: time.Date(2023, 3, 2, 1, 15, 0, 0, time.UTC)},
+		{name: "default time zone", time: "2023-03-02 10:15", want: time.Date(2023, 3, 2, 1, 15, 0, 0, time.UTC)},
+		{name: "malformed time", time: "2023/03/02 10:15", wantErr: sens.ErrInvalidReserveTime},
+		{name: "unknown time zone", time: "2023-03-02 10:15", timeZone: "Mars/Olympus_Mons", wantErr: sens.ErrInvalidReserveTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sens.ParseReserveTime(tt.time, tt.timeZone)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v but got: %v", tt.wantErr, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Expected %v but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldCheckTheReserveTime(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusName":"success"}`)
+	})
+
+	// The client clock is fixed to 1997-02-26 00:00 UTC.
+	tests := []struct {
+		name    string
+		t       time.Time
+		wantErr error
+	}{
+		{name: "in the window", t: time.Date(1997, 2, 26, 9, 30, 0, 0, sens.KST)},
+		{name: "current minute", t: time.Date(1997, 2, 26, 0, 0, 0, 0, time.UTC)},
+		{name: "in the past", t: time.Date(1997, 2, 26, 8, 59, 0, 0, sens.KST), wantErr: sens.ErrReserveTimeInPast},
+		{name: "beyond the window", t: time.Date(1997, 8, 26, 0, 0, 0, 0, time.UTC), wantErr: sens.ErrReserveTimeTooFar},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := sens.SendSMSRequest{}.ReserveAt(tt.t)
+			if err != nil {
+				t.Fatalf("ReserveAt was given a valid time but failed: %v", err)
+			}
+
+			_, err = client.SendSMS(context.Background(), req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v but got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSENSClient_GetReservationStatus_ShouldRoundTripTheReserveTime(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.ReservationEndpoint(testServiceID, "test-reserve-id")+"/reserve-status", func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, `{"reserveId":"test-reserve-id","reserveTimeZone":"Asia/Seoul","reserveTime":"1997-03-01 10:15:00","reserveStatus":"READY"}`)
+	})
+
+	status, err := client.GetReservationStatus(context.Background(), "test-reserve-id")
+	if err != nil {
+		t.Fatalf("Get reservation status request was given a valid request but failed: %v", err)
+	}
+
+	got, err := status.ReservedAt()
+	if err != nil {
+		t.Fatalf("ReservedAt failed: %v", err)
+	}
+	if want := time.Date(1997, 3, 1, 10, 15, 0, 0, sens.KST); !got.Equal(want) {
+		t.Errorf("Expected reserve time %v but got %v", want, got)
+	}
+}
@@ -3,9 +3,14 @@ package sens_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/connectfit-team/naverapi"
 	"github.com/connectfit-team/naverapi/internal/testhelper"
 	"github.com/connectfit-team/naverapi/sens"
 	"github.com/google/go-cmp/cmp"
@@ -15,15 +20,15 @@ func TestSENSClient_SendSMS(t *testing.T) {
 	client, mux, teardown := setupTestSENSClient()
 	defer teardown()
 
-	mux.HandleFunc(sens.EndpointMessages, func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
 		testhelper.TestRequestMethod(t, r, http.MethodPost)
 
 		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Timestamp", "856915200000")
 		testhelper.TestRequestHeader(t, r, "X-Ncp-Iam-Access-Key", "test-access-key")
-		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "fNdhE7DMQ3QT2Ev3GxZxSpzg3vJmHNBMjAPG78IqBX8=")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "Ne7HsxhMeBgYaqhHnFZvEifE8IJs80sKmP7mf8zEO18=")
 		testhelper.TestRequestHeader(t, r, "Content-Type", "application/json")
 
-		testhelper.TestRequestBody(t, r, `{"type":"LMS","contentType":"AD","countryCode":"82","from":"test-from","subject":"test-subject","content":"test-content","messages":[{"to":"test-to-1","subject":"test-subject-1","content":"test-content-1"},{"to":"test-to-2","subject":"test-subject-2","content":"test-content-2"}],"reserveTime":"test-reserve-time","reserveTimeZone":"test-reserve-time-zone","scheduleCode":"test-schedule-code"}`)
+		testhelper.TestRequestBody(t, r, `{"type":"LMS","contentType":"AD","countryCode":"82","from":"test-from","subject":"test-subject","content":"test-content","messages":[{"to":"test-to-1","subject":"test-subject-1","content":"test-content-1"},{"to":"test-to-2","subject":"test-subject-2","content":"test-content-2"}],"reserveTime":"1997-03-01 10:15","reserveTimeZone":"Asia/Seoul","scheduleCode":"test-schedule-code"}`)
 
 		w.WriteHeader(http.StatusAccepted)
 		respBody := sens.SendSMSResponse{
@@ -57,8 +62,8 @@ func TestSENSClient_SendSMS(t *testing.T) {
 				Content: "test-content-2",
 			},
 		},
-		ReserveTime:     "test-reserve-time",
-		ReserveTimeZone: "test-reserve-time-zone",
+		ReserveTime:     "1997-03-01 10:15",
+		ReserveTimeZone: "Asia/Seoul",
 		ScheduleCode:    "test-schedule-code",
 	}
 	got, err := client.SendSMS(context.Background(), req)
@@ -81,15 +86,15 @@ func TestSENSClient_SendSMS_ShouldFailIfWrongResponseStatusCode(t *testing.T) {
 	client, mux, teardown := setupTestSENSClient()
 	defer teardown()
 
-	mux.HandleFunc(sens.EndpointMessages, func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
 		testhelper.TestRequestMethod(t, r, http.MethodPost)
 
 		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Timestamp", "856915200000")
 		testhelper.TestRequestHeader(t, r, "X-Ncp-Iam-Access-Key", "test-access-key")
-		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "fNdhE7DMQ3QT2Ev3GxZxSpzg3vJmHNBMjAPG78IqBX8=")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "Ne7HsxhMeBgYaqhHnFZvEifE8IJs80sKmP7mf8zEO18=")
 		testhelper.TestRequestHeader(t, r, "Content-Type", "application/json")
 
-		testhelper.TestRequestBody(t, r, `{"type":"LMS","contentType":"AD","countryCode":"82","from":"test-from","subject":"test-subject","content":"test-content","messages":[{"to":"test-to-1","subject":"test-subject-1","content":"test-content-1"},{"to":"test-to-2","subject":"test-subject-2","content":"test-content-2"}],"reserveTime":"test-reserve-time","reserveTimeZone":"test-reserve-time-zone","scheduleCode":"test-schedule-code"}`)
+		testhelper.TestRequestBody(t, r, `{"type":"LMS","contentType":"AD","countryCode":"82","from":"test-from","subject":"test-subject","content":"test-content","messages":[{"to":"test-to-1","subject":"test-subject-1","content":"test-content-1"},{"to":"test-to-2","subject":"test-subject-2","content":"test-content-2"}],"reserveTime":"1997-03-01 10:15","reserveTimeZone":"Asia/Seoul","scheduleCode":"test-schedule-code"}`)
 
 		w.WriteHeader(http.StatusBadRequest)
 	})
@@ -113,8 +118,8 @@ func TestSENSClient_SendSMS_ShouldFailIfWrongResponseStatusCode(t *testing.T) {
 				Content: "test-content-2",
 			},
 		},
-		ReserveTime:     "test-reserve-time",
-		ReserveTimeZone: "test-reserve-time-zone",
+		ReserveTime:     "1997-03-01 10:15",
+		ReserveTimeZone: "Asia/Seoul",
 		ScheduleCode:    "test-schedule-code",
 	}
 	_, err := client.SendSMS(context.Background(), req)
@@ -127,15 +132,15 @@ func TestSENSClient_SendSMS_ShouldFailIfMalformedResponseBody(t *testing.T) {
 	client, mux, teardown := setupTestSENSClient()
 	defer teardown()
 
-	mux.HandleFunc(sens.EndpointMessages, func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
 		testhelper.TestRequestMethod(t, r, http.MethodPost)
 
 		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Timestamp", "856915200000")
 		testhelper.TestRequestHeader(t, r, "X-Ncp-Iam-Access-Key", "test-access-key")
-		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "fNdhE7DMQ3QT2Ev3GxZxSpzg3vJmHNBMjAPG78IqBX8=")
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "Ne7HsxhMeBgYaqhHnFZvEifE8IJs80sKmP7mf8zEO18=")
 		testhelper.TestRequestHeader(t, r, "Content-Type", "application/json")
 
-		testhelper.TestRequestBody(t, r, `{"type":"LMS","contentType":"AD","countryCode":"82","from":"test-from","subject":"test-subject","content":"test-content","messages":[{"to":"test-to-1","subject":"test-subject-1","content":"test-content-1"},{"to":"test-to-2","subject":"test-subject-2","content":"test-content-2"}],"reserveTime":"test-reserve-time","reserveTimeZone":"test-reserve-time-zone","scheduleCode":"test-schedule-code"}`)
+		testhelper.TestRequestBody(t, r, `{"type":"LMS","contentType":"AD","countryCode":"82","from":"test-from","subject":"test-subject","content":"test-content","messages":[{"to":"test-to-1","subject":"test-subject-1","content":"test-content-1"},{"to":"test-to-2","subject":"test-subject-2","content":"test-content-2"}],"reserveTime":"1997-03-01 10:15","reserveTimeZone":"Asia/Seoul","scheduleCode":"test-schedule-code"}`)
 
 		w.WriteHeader(http.StatusBadRequest)
 		w.Write([]byte("malformed response body :)"))
@@ -160,8 +165,8 @@ func TestSENSClient_SendSMS_ShouldFailIfMalformedResponseBody(t *testing.T) {
 				Content: "test-content-2",
 			},
 		},
-		ReserveTime:     "test-reserve-time",
-		ReserveTimeZone: "test-reserve-time-zone",
+		ReserveTime:     "1997-03-01 10:15",
+		ReserveTimeZone: "Asia/Seoul",
 		ScheduleCode:    "test-schedule-code",
 	}
 	_, err := client.SendSMS(context.Background(), req)
@@ -169,3 +174,201 @@ func TestSENSClient_SendSMS_ShouldFailIfMalformedResponseBody(t *testing.T) {
 		t.Fatalf("Send SMS request should fail when the server send malformed response body")
 	}
 }
+
+func TestSENSClient_SendSMS_ShouldFailIfMissingServiceID(t *testing.T) {
+	client, _, teardown := setupTestSENSClient()
+	defer teardown()
+
+	_, err := client.WithServiceID("").SendSMS(context.Background(), sens.SendSMSRequest{})
+	if !errors.Is(err, sens.ErrMissingServiceID) {
+		t.Fatalf("Send SMS request should fail with %v when no service ID is set but got: %v", sens.ErrMissingServiceID, err)
+	}
+}
+
+func TestSENSClient_WithServiceID(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	const otherServiceID = "ncp:sms:kr:123456789012:other_service"
+	mux.HandleFunc(sens.MessagesEndpoint(otherServiceID), func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestRequestMethod(t, r, http.MethodPost)
+
+		testhelper.TestRequestHeader(t, r, "X-Ncp-Apigw-Signature-V2", "8Xx8FCL4Oq6fpHHuwd0Mp4iw/shHqETSV7+vSCjCPaM=")
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusName":"success"}`)
+	})
+
+	other := client.WithServiceID(otherServiceID)
+	if client.ServiceID != testServiceID {
+		t.Errorf("WithServiceID shouldn't modify the original client service ID but got %q", client.ServiceID)
+	}
+
+	got, err := other.SendSMS(context.Background(), sens.SendSMSRequest{})
+	if err != nil {
+		t.Fatalf("Send SMS request was given a valid request but failed: %v", err)
+	}
+
+	want := sens.SendSMSResponse{
+		RequestID:  "test-request-id",
+		StatusName: "success",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Response differ from the expected one: %s", diff)
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldFailIfStatusNameIsNotSuccess(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusCode":"400","statusName":"fail"}`)
+	})
+
+	_, err := client.SendSMS(context.Background(), sens.SendSMSRequest{})
+	if !errors.Is(err, sens.ErrSendSMSFailed) {
+		t.Fatalf("Send SMS request should fail with %v but got: %v", sens.ErrSendSMSFailed, err)
+	}
+
+	var apiErr *naverapi.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Send SMS request should fail with a *naverapi.APIError but got: %v", err)
+	}
+	if apiErr.Code != "400" || apiErr.RequestID != "test-request-id" {
+		t.Errorf("unexpected API error: %+v", apiErr)
+	}
+}
+
+type incrementingClock struct {
+	now time.Time
+}
+
+func (ic *incrementingClock) Now() time.Time {
+	ic.now = ic.now.Add(time.Second)
+	return ic.now
+}
+
+func TestSENSClient_SendSMS_ShouldSignEachRetry(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.Clock = &incrementingClock{now: time.Date(1997, 02, 26, 0, 0, 0, 0, time.UTC)}
+	client.RetryPolicy = naverapi.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+
+	var timestamps []string
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		timestamps = append(timestamps, r.Header.Get("X-Ncp-Apigw-Timestamp"))
+		if len(timestamps) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusName":"success"}`)
+	})
+
+	_, err := client.SendSMS(context.Background(), sens.SendSMSRequest{})
+	if err != nil {
+		t.Fatalf("Send SMS request should succeed after retrying but failed: %v", err)
+	}
+
+	want := []string{"856915201000", "856915202000"}
+	if diff := cmp.Diff(timestamps, want); diff != "" {
+		t.Errorf("Request timestamps differ from the expected ones: %s", diff)
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldNotRetryIfDisabled(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	client.RetryPolicy = naverapi.NoRetry
+
+	var attempts int
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := client.SendSMS(context.Background(), sens.SendSMSRequest{})
+	if !naverapi.IsRetryable(err) {
+		t.Fatalf("Send SMS request should fail with a retryable error but got: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Send SMS request should be sent once but was sent %d times", attempts)
+	}
+}
+
+type countingLimiter struct {
+	mu    sync.Mutex
+	waits int
+}
+
+func (cl *countingLimiter) Wait(ctx context.Context) error {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	cl.waits++
+	return nil
+}
+
+func TestSENSClient_SendSMS_ShouldWaitForSharedLimiter(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	limiter := &countingLimiter{}
+	client.Limiter = limiter
+
+	const otherServiceID = "ncp:sms:kr:123456789012:other_service"
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusName":"success"}`)
+	}
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), handler)
+	mux.HandleFunc(sens.MessagesEndpoint(otherServiceID), handler)
+
+	for _, c := range []*sens.Client{client, client.WithServiceID(otherServiceID)} {
+		_, err := c.SendSMS(context.Background(), sens.SendSMSRequest{})
+		if err != nil {
+			t.Fatalf("Send SMS request was given a valid request but failed: %v", err)
+		}
+	}
+
+	if limiter.waits != 2 {
+		t.Errorf("Expected the shared limiter to be waited for twice but got %d", limiter.waits)
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldResolveCredentialsForEachRequest(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	keys := []string{"first-access-key", "rotated-access-key"}
+	var calls int
+	client.Credentials = naverapi.CredentialsProviderFunc(func(ctx context.Context) (naverapi.Credentials, error) {
+		creds := naverapi.Credentials{AccessKey: keys[calls], SecretKey: "test-secret-key"}
+		calls++
+		return creds, nil
+	})
+
+	var got []string
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-Ncp-Iam-Access-Key"))
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusName":"success"}`)
+	})
+
+	for range keys {
+		_, err := client.SendSMS(context.Background(), sens.SendSMSRequest{})
+		if err != nil {
+			t.Fatalf("Send SMS request was given a valid request but failed: %v", err)
+		}
+	}
+
+	if diff := cmp.Diff(got, keys); diff != "" {
+		t.Errorf("Access keys differ from the expected ones: %s", diff)
+	}
+}
@@ -0,0 +1,124 @@
+package sens
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/korean"
+)
+
+// SMSTypeAuto makes SendSMS choose the type of the messages from the length
+// of their content. It is never sent to the API.
+const SMSTypeAuto SMSType = "AUTO"
+
+const (
+	// MaxSMSContentBytes is the maximum length in EUC-KR bytes of the content
+	// of an SMS.
+	MaxSMSContentBytes = 90
+	// MaxLMSContentBytes is the maximum length in EUC-KR bytes of the content
+	// of an LMS or MMS.
+	MaxLMSContentBytes = 2000
+	// MaxLMSSubjectBytes is the maximum length in EUC-KR bytes of the subject
+	// of an LMS or MMS.
+	MaxLMSSubjectBytes = 40
+)
+
+var (
+	// ErrUnsupportedCharacter is returned when a message holds a character
+	// which can't be encoded in EUC-KR, such as an emoji.
+	ErrUnsupportedCharacter = errors.New("the character can't be encoded in EUC-KR")
+	// ErrContentTooLong is returned when the content of a message exceeds
+	// MaxLMSContentBytes.
+	ErrContentTooLong = errors.New("the message content is too long")
+	// ErrSubjectTooLong is returned when the subject of a message exceeds
+	// MaxLMSSubjectBytes.
+	ErrSubjectTooLong = errors.New("the message subject is too long")
+)
+
+// EUCKRLength returns the length in bytes of the given string once encoded in
+// EUC-KR, as counted by the SENS SMS API.
+// It returns an error wrapping ErrUnsupportedCharacter if the string holds a
+// character which can't be encoded in EUC-KR.
+func EUCKRLength(s string) (int, error) {
+	enc := korean.EUCKR.NewEncoder()
+
+	n := 0
+	for i, r := range s {
+		if r < utf8.RuneSelf {
+			n++
+			continue
+		}
+		b, err := enc.String(string(r))
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q at byte %d", ErrUnsupportedCharacter, r, i)
+		}
+		n += len(b)
+	}
+	return n, nil
+}
+
+// SelectSMSType returns the type the messages of the given request should be
+// sent as: SMSTypeMMS if it has files, SMSTypeLMS if a message has a content
+// longer than MaxSMSContentBytes and SMSTypeSMS otherwise.
+// The content and subject of each message are the ones of the request unless
+// overridden by the message. The subjects don't make a message an LMS: they
+// are only checked, and sent, if the messages are LMS or MMS.
+// It returns an error wrapping ErrUnsupportedCharacter, ErrContentTooLong or
+// ErrSubjectTooLong if a message can't be sent.
+func SelectSMSType(req SendSMSRequest) (SMSType, error) {
+	typ := SMSTypeSMS
+	if len(req.Files) > 0 {
+		typ = SMSTypeMMS
+	}
+
+	messages := req.Messages
+	if len(messages) == 0 {
+		messages = []Message{{}}
+	}
+	var subjectErr error
+	for i, msg := range messages {
+		content, subject := req.Content, req.Subject
+		if msg.Content != "" {
+			content = msg.Content
+		}
+		if msg.Subject != "" {
+			subject = msg.Subject
+		}
+
+		contentLen, err := EUCKRLength(content)
+		if err != nil {
+			return "", fmt.Errorf("content of message %d: %w", i, err)
+		}
+		if contentLen > MaxLMSContentBytes {
+			return "", fmt.Errorf("%w: message %d is %d bytes long, at most %d bytes are allowed", ErrContentTooLong, i, contentLen, MaxLMSContentBytes)
+		}
+
+		if typ == SMSTypeSMS && contentLen > MaxSMSContentBytes {
+			typ = SMSTypeLMS
+		}
+
+		if subjectErr == nil {
+			subjectErr = checkSubject(i, subject)
+		}
+	}
+
+	if typ != SMSTypeSMS && subjectErr != nil {
+		return "", subjectErr
+	}
+
+	return typ, nil
+}
+
+// checkSubject checks that the given subject of the i-th message can be sent
+// with an LMS or MMS.
+func checkSubject(i int, subject string) error {
+	subjectLen, err := EUCKRLength(subject)
+	if err != nil {
+		return fmt.Errorf("subject of message %d: %w", i, err)
+	}
+	if subjectLen > MaxLMSSubjectBytes {
+		return fmt.Errorf("%w: the subject of message %d is %d bytes long, at most %d bytes are allowed", ErrSubjectTooLong, i, subjectLen, MaxLMSSubjectBytes)
+	}
+	return nil
+}
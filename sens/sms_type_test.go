@@ -0,0 +1,158 @@
+package sens_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/connectfit-team/naverapi/sens"
+)
+
+func TestEUCKRLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    int
+		wantErr error
+	}{
+		{name: "ASCII", s: "Hello, World!", want: 13},
+		{name: "Hangul", s: "안녕하세요", want: 10},
+		{name: "mixed", s: "[Web발신] 인증번호 123456", want: 25},
+		{name: "emoji", s: "안녕 😀", wantErr: sens.ErrUnsupportedCharacter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sens.EUCKRLength(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v but got: %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %d bytes but got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSelectSMSType(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     sens.SendSMSRequest
+		want    sens.SMSType
+		wantErr error
+	}{
+		{
+			name: "short content",
+			req:  sens.SendSMSRequest{Content: strings.Repeat("가", 45), Messages: []sens.Message{{To: "01012345678"}}},
+			want: sens.SMSTypeSMS,
+		},
+		{
+			name: "long content",
+			req:  sens.SendSMSRequest{Content: strings.Repeat("가", 46), Messages: []sens.Message{{To: "01012345678"}}},
+			want: sens.SMSTypeLMS,
+		},
+		{
+			name: "long message override",
+			req: sens.SendSMSRequest{
+				Content:  "짧은 내용",
+				Messages: []sens.Message{{To: "01012345678"}, {To: "01087654321", Content: strings.Repeat("a", 91)}},
+			},
+			want: sens.SMSTypeLMS,
+		},
+		{
+			name: "subject of a short message",
+			req:  sens.SendSMSRequest{Content: "짧은 내용", Messages: []sens.Message{{To: "01012345678", Subject: "제목"}}},
+			want: sens.SMSTypeSMS,
+		},
+		{
+			name: "files",
+			req:  sens.SendSMSRequest{Content: "짧은 내용", Files: []sens.File{{FileID: "test-file-id"}}},
+			want: sens.SMSTypeMMS,
+		},
+		{
+			name:    "content too long",
+			req:     sens.SendSMSRequest{Content: strings.Repeat("가", 1001)},
+			wantErr: sens.ErrContentTooLong,
+		},
+		{
+			name: "subject too long for a short message",
+			req:  sens.SendSMSRequest{Content: "짧은 내용", Subject: strings.Repeat("제목", 11)},
+			want: sens.SMSTypeSMS,
+		},
+		{
+			name:    "subject too long",
+			req:     sens.SendSMSRequest{Content: strings.Repeat("a", 91), Subject: strings.Repeat("제목", 11)},
+			wantErr: sens.ErrSubjectTooLong,
+		},
+		{
+			name: "emoji in message override",
+			req: sens.SendSMSRequest{
+				Content:  "짧은 내용",
+				Messages: []sens.Message{{To: "01012345678", Content: "생일 축하해요 🎂"}},
+			},
+			wantErr: sens.ErrUnsupportedCharacter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sens.SelectSMSType(tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v but got: %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected type %q but got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldSelectTheTypeInAutoMode(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		var req sens.SendSMSRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			t.Errorf("could not decode the request body: %v", err)
+		}
+		if req.Type != sens.SMSTypeLMS {
+			t.Errorf("Expected type %q but got %q", sens.SMSTypeLMS, req.Type)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"requestId":"test-request-id","statusCode":"202","statusName":"success"}`)
+	})
+
+	_, err := client.SendSMS(context.Background(), sens.SendSMSRequest{
+		Type:        sens.SMSTypeAuto,
+		ContentType: sens.ContentTypeSMS,
+		From:        "0212345678",
+		Content:     strings.Repeat("가", 46),
+		Messages:    []sens.Message{{To: "01012345678"}},
+	})
+	if err != nil {
+		t.Fatalf("Send SMS request was given a valid request but failed: %v", err)
+	}
+}
+
+func TestSENSClient_SendSMS_ShouldNotSendUnencodableContentInAutoMode(t *testing.T) {
+	client, mux, teardown := setupTestSENSClient()
+	defer teardown()
+
+	mux.HandleFunc(sens.MessagesEndpoint(testServiceID), func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("A message which can't be encoded in EUC-KR shouldn't be sent")
+	})
+
+	_, err := client.SendSMS(context.Background(), sens.SendSMSRequest{
+		Type:     sens.SMSTypeAuto,
+		Content:  "😀",
+		Messages: []sens.Message{{To: "01012345678"}},
+	})
+	if !errors.Is(err, sens.ErrUnsupportedCharacter) {
+		t.Errorf("Expected error %v but got: %v", sens.ErrUnsupportedCharacter, err)
+	}
+}
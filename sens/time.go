@@ -0,0 +1,59 @@
+package sens
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// DateTimeLayout is the layout of the date times returned by the SENS API.
+const DateTimeLayout = "2006-01-02 15:04:05"
+
+// KST is the Korea Standard Time zone in which the SENS API returns its date
+// times.
+var KST = time.FixedZone("KST", 9*60*60)
+
+// dateTimeLayouts are the layouts of the date times found in the SENS API
+// responses.
+var dateTimeLayouts = []string{
+	DateTimeLayout,
+	"2006-01-02T15:04:05.000",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+}
+
+// Time is a date time returned by the SENS API, which are expressed in KST
+// without time zone.
+type Time struct {
+	time.Time
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
+	}
+	return []byte(`"` + t.In(KST).Format(DateTimeLayout) + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(bytes.Trim(data, `"`))
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	for _, layout := range dateTimeLayouts {
+		parsed, err := time.ParseInLocation(layout, s, KST)
+		if err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("could not parse %q as a SENS date time", s)
+}
@@ -0,0 +1,59 @@
+package naverapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrUnknownSite is returned when parsing an unknown site name.
+var ErrUnknownSite = errors.New("unknown site")
+
+// Site is a Naver Cloud Platform site. Each site has its own API gateway
+// hosts, e.g. sens.apigw.ntruss.com for the public site and
+// sens.apigw.fin-ntruss.com for the Financial one.
+//
+// The regions (KR, SG, JP...) of the public site share the same hosts.
+type Site string
+
+const (
+	SitePublic    Site = "public" // 민간
+	SiteGov       Site = "gov"    // 공공
+	SiteFinancial Site = "fin"    // 금융
+)
+
+// ParseSite returns the site matching the given name.
+func ParseSite(name string) (Site, error) {
+	switch strings.ToLower(name) {
+	case "", "public", "pub":
+		return SitePublic, nil
+	case "gov":
+		return SiteGov, nil
+	case "fin", "financial":
+		return SiteFinancial, nil
+	default:
+		return "", fmt.Errorf("%w %q", ErrUnknownSite, name)
+	}
+}
+
+// Domain returns the domain of the API gateway of the site.
+func (s Site) Domain() string {
+	switch s {
+	case SiteGov:
+		return "apigw.gov-ntruss.com"
+	case SiteFinancial:
+		return "apigw.fin-ntruss.com"
+	default:
+		return "apigw.ntruss.com"
+	}
+}
+
+// BaseURL returns the base URL of the given API on the site given its
+// gateway host prefix, e.g. "sens" or "mail".
+func (s Site) BaseURL(api string) *url.URL {
+	return &url.URL{
+		Scheme: "https",
+		Host:   api + "." + s.Domain(),
+	}
+}
@@ -0,0 +1,39 @@
+package naverapi_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/connectfit-team/naverapi"
+)
+
+func TestSite_BaseURL(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "public", want: "https://sens.apigw.ntruss.com"},
+		{name: "", want: "https://sens.apigw.ntruss.com"},
+		{name: "gov", want: "https://sens.apigw.gov-ntruss.com"},
+		{name: "FIN", want: "https://sens.apigw.fin-ntruss.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site, err := naverapi.ParseSite(tt.name)
+			if err != nil {
+				t.Fatalf("parsing a known site shouldn't fail but got: %v", err)
+			}
+
+			if got := site.BaseURL("sens").String(); got != tt.want {
+				t.Errorf("expected base URL %q but got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseSite_ShouldFailIfUnknownSite(t *testing.T) {
+	_, err := naverapi.ParseSite("moon")
+	if !errors.Is(err, naverapi.ErrUnknownSite) {
+		t.Errorf("expected error %v but got: %v", naverapi.ErrUnknownSite, err)
+	}
+}
@@ -0,0 +1,228 @@
+package naverapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/connectfit-team/naverapi/internal/httputil"
+)
+
+// DefaultMaxSkew is the maximum difference between the timestamp of a request
+// and the current time tolerated by the API gateway.
+const DefaultMaxSkew = 5 * time.Minute
+
+var (
+	// ErrUnknownAccessKey should be returned by a SecretLookup which does not
+	// know the given access key.
+	ErrUnknownAccessKey = errors.New("unknown access key")
+	// ErrMissingSecretLookup is wrapped by the *VerificationError returned by
+	// a Verifier without LookupSecret.
+	ErrMissingSecretLookup = errors.New("the verifier has no secret lookup")
+)
+
+// SecretLookup returns the secret key associated to the given access key.
+// It should return an error wrapping ErrUnknownAccessKey if the access key is
+// unknown.
+type SecretLookup func(ctx context.Context, accessKey string) (secretKey string, err error)
+
+// VerificationFailureReason is the reason why a request has been rejected.
+type VerificationFailureReason string
+
+const (
+	ReasonMissingHeader      VerificationFailureReason = "missing_header"
+	ReasonMalformedTimestamp VerificationFailureReason = "malformed_timestamp"
+	ReasonStaleTimestamp     VerificationFailureReason = "stale_timestamp"
+	ReasonFutureTimestamp    VerificationFailureReason = "future_timestamp"
+	ReasonUnknownAccessKey   VerificationFailureReason = "unknown_access_key"
+	ReasonLookupFailed       VerificationFailureReason = "lookup_failed"
+	ReasonInvalidSignature   VerificationFailureReason = "invalid_signature"
+)
+
+// VerificationError is returned when a request signature can't be verified.
+type VerificationError struct {
+	// Reason is the reason why the request has been rejected.
+	Reason VerificationFailureReason
+	// AccessKey is the access key of the request, if any.
+	AccessKey string
+	// Err is the underlying error, if any.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *VerificationError) Error() string {
+	msg := fmt.Sprintf("could not verify the request signature: %s", e.Reason)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error, if any.
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
+// Verifier verifies the `x-ncp-apigw-signature-v2` signature of the requests
+// received by a server, as the Naver Cloud Platform API gateway does.
+type Verifier struct {
+	// LookupSecret returns the secret key of an access key.
+	LookupSecret SecretLookup
+	// MaxSkew is the maximum difference tolerated between the
+	// `x-ncp-apigw-timestamp` of a request and the current time.
+	// Defaults to DefaultMaxSkew.
+	MaxSkew time.Duration
+	// Clock provides the current time. The system time is used if nil.
+	Clock interface{ Now() time.Time }
+}
+
+// VerifyRequest verifies the signature of the given request, rejecting the
+// requests whose timestamp differs from the current time by more than
+// maxSkew. The returned error is a *VerificationError.
+func VerifyRequest(r *http.Request, lookupSecret SecretLookup, maxSkew time.Duration) error {
+	v := Verifier{
+		LookupSecret: lookupSecret,
+		MaxSkew:      maxSkew,
+	}
+	return v.Verify(r)
+}
+
+// Verify verifies the signature of the given request.
+// The returned error is a *VerificationError.
+func (v Verifier) Verify(r *http.Request) error {
+	accessKey := r.Header.Get(httputil.HeaderAccessKey)
+	timestamp := r.Header.Get(httputil.HeaderTimestamp)
+	signature := r.Header.Get(httputil.HeaderSignatureV2)
+
+	for _, h := range []struct{ name, value string }{
+		{name: httputil.HeaderAccessKey, value: accessKey},
+		{name: httputil.HeaderTimestamp, value: timestamp},
+		{name: httputil.HeaderSignatureV2, value: signature},
+	} {
+		if h.value == "" {
+			return &VerificationError{
+				Reason:    ReasonMissingHeader,
+				AccessKey: accessKey,
+				Err:       fmt.Errorf("the %s header is missing", h.name),
+			}
+		}
+	}
+
+	reason, err := v.verifyTimestamp(timestamp)
+	if err != nil {
+		return &VerificationError{Reason: reason, AccessKey: accessKey, Err: err}
+	}
+
+	if v.LookupSecret == nil {
+		return &VerificationError{Reason: ReasonLookupFailed, AccessKey: accessKey, Err: ErrMissingSecretLookup}
+	}
+	secretKey, err := v.LookupSecret(r.Context(), accessKey)
+	if err != nil {
+		reason := ReasonLookupFailed
+		if errors.Is(err, ErrUnknownAccessKey) {
+			reason = ReasonUnknownAccessKey
+		}
+		return &VerificationError{Reason: reason, AccessKey: accessKey, Err: err}
+	}
+
+	want, err := httputil.FormatAPIGatewaySignature(r.Method, httputil.SignatureURI(r), timestamp, accessKey, secretKey)
+	if err != nil {
+		return &VerificationError{Reason: ReasonInvalidSignature, AccessKey: accessKey, Err: err}
+	}
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(want)) != 1 {
+		return &VerificationError{Reason: ReasonInvalidSignature, AccessKey: accessKey}
+	}
+
+	return nil
+}
+
+func (v Verifier) verifyTimestamp(timestamp string) (VerificationFailureReason, error) {
+	millis, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ReasonMalformedTimestamp, err
+	}
+
+	now := time.Now()
+	if v.Clock != nil {
+		now = v.Clock.Now()
+	}
+	maxSkew := v.MaxSkew
+	if maxSkew <= 0 {
+		maxSkew = DefaultMaxSkew
+	}
+
+	skew := now.Sub(time.UnixMilli(millis))
+	switch {
+	case skew > maxSkew:
+		return ReasonStaleTimestamp, fmt.Errorf("the request has been signed %v ago", skew)
+	case -skew > maxSkew:
+		return ReasonFutureTimestamp, fmt.Errorf("the request has been signed %v in the future", -skew)
+	default:
+		return "", nil
+	}
+}
+
+type verifiedAccessKeyContextKey struct{}
+
+// VerifiedAccessKey returns the access key of the request verified by the
+// middleware, if any.
+func VerifiedAccessKey(ctx context.Context) (string, bool) {
+	accessKey, ok := ctx.Value(verifiedAccessKeyContextKey{}).(string)
+	return accessKey, ok
+}
+
+// Middleware returns a handler verifying the signature of each request before
+// handing it to next. Rejected requests are answered with the 401 status code
+// and an error body shaped as the API gateway's one.
+// The access key of the accepted requests is available with
+// VerifiedAccessKey.
+func (v Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := v.Verify(r)
+		if err != nil {
+			writeVerificationError(w, err)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), verifiedAccessKeyContextKey{}, r.Header.Get(httputil.HeaderAccessKey))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// VerifyMiddleware returns a handler verifying the signature of each request
+// before handing it to next. See Verifier.Middleware.
+func VerifyMiddleware(lookupSecret SecretLookup, maxSkew time.Duration, next http.Handler) http.Handler {
+	v := Verifier{
+		LookupSecret: lookupSecret,
+		MaxSkew:      maxSkew,
+	}
+	return v.Middleware(next)
+}
+
+func writeVerificationError(w http.ResponseWriter, err error) {
+	statusCode, errorCode, message := http.StatusUnauthorized, "200", "Authentication Failed"
+	details := "Invalid authentication information."
+
+	var verr *VerificationError
+	if errors.As(err, &verr) {
+		details = string(verr.Reason)
+		if verr.Reason == ReasonLookupFailed {
+			statusCode, errorCode, message = http.StatusInternalServerError, "500", "Internal Server Error"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]any{
+		"error": map[string]string{
+			"errorCode": errorCode,
+			"message":   message,
+			"details":   details,
+		},
+	})
+}
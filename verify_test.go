@@ -0,0 +1,183 @@
+package naverapi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/connectfit-team/naverapi"
+	"github.com/connectfit-team/naverapi/internal/httputil"
+)
+
+type fixedTimeClock struct {
+	fixedTime time.Time
+}
+
+func (ftc fixedTimeClock) Now() time.Time { return ftc.fixedTime }
+
+var testSigningTime = time.Date(1997, 02, 26, 0, 0, 0, 0, time.UTC)
+
+func lookupTestSecret(ctx context.Context, accessKey string) (string, error) {
+	if accessKey != "test-access-key" {
+		return "", fmt.Errorf("%w: %s", naverapi.ErrUnknownAccessKey, accessKey)
+	}
+	return "test-secret-key", nil
+}
+
+func newSignedTestRequest(t *testing.T, accessKey, secretKey string) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/internal/v1/users?page=2", nil)
+	signer := httputil.Signer{
+		Credentials: httputil.StaticCredentials(accessKey, secretKey),
+		Clock:       fixedTimeClock{fixedTime: testSigningTime},
+	}
+	err := signer.Sign(req)
+	if err != nil {
+		t.Fatalf("could not sign the request: %v", err)
+	}
+	return req
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        func(t *testing.T) *http.Request
+		now        time.Time
+		wantReason naverapi.VerificationFailureReason
+	}{
+		{
+			name: "valid signature",
+			req: func(t *testing.T) *http.Request {
+				return newSignedTestRequest(t, "test-access-key", "test-secret-key")
+			},
+			now: testSigningTime.Add(time.Minute),
+		},
+		{
+			name: "invalid signature",
+			req: func(t *testing.T) *http.Request {
+				return newSignedTestRequest(t, "test-access-key", "wrong-secret-key")
+			},
+			now:        testSigningTime,
+			wantReason: naverapi.ReasonInvalidSignature,
+		},
+		{
+			name: "tampered query",
+			req: func(t *testing.T) *http.Request {
+				req := newSignedTestRequest(t, "test-access-key", "test-secret-key")
+				req.URL.RawQuery = "page=3"
+				return req
+			},
+			now:        testSigningTime,
+			wantReason: naverapi.ReasonInvalidSignature,
+		},
+		{
+			name: "unknown access key",
+			req: func(t *testing.T) *http.Request {
+				return newSignedTestRequest(t, "unknown-access-key", "test-secret-key")
+			},
+			now:        testSigningTime,
+			wantReason: naverapi.ReasonUnknownAccessKey,
+		},
+		{
+			name: "stale timestamp",
+			req: func(t *testing.T) *http.Request {
+				return newSignedTestRequest(t, "test-access-key", "test-secret-key")
+			},
+			now:        testSigningTime.Add(10 * time.Minute),
+			wantReason: naverapi.ReasonStaleTimestamp,
+		},
+		{
+			name: "future timestamp",
+			req: func(t *testing.T) *http.Request {
+				return newSignedTestRequest(t, "test-access-key", "test-secret-key")
+			},
+			now:        testSigningTime.Add(-10 * time.Minute),
+			wantReason: naverapi.ReasonFutureTimestamp,
+		},
+		{
+			name: "missing signature",
+			req: func(t *testing.T) *http.Request {
+				req := newSignedTestRequest(t, "test-access-key", "test-secret-key")
+				req.Header.Del("x-ncp-apigw-signature-v2")
+				return req
+			},
+			now:        testSigningTime,
+			wantReason: naverapi.ReasonMissingHeader,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := naverapi.Verifier{
+				LookupSecret: lookupTestSecret,
+				Clock:        fixedTimeClock{fixedTime: tt.now},
+			}
+
+			err := v.Verify(tt.req(t))
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("verifying a valid request shouldn't fail but got: %v", err)
+				}
+				return
+			}
+
+			var verr *naverapi.VerificationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected a *VerificationError but got: %v", err)
+			}
+			if verr.Reason != tt.wantReason {
+				t.Errorf("expected reason %q but got %q", tt.wantReason, verr.Reason)
+			}
+		})
+	}
+}
+
+func TestVerifier_Middleware(t *testing.T) {
+	v := naverapi.Verifier{
+		LookupSecret: lookupTestSecret,
+		Clock:        fixedTimeClock{fixedTime: testSigningTime},
+	}
+
+	var gotAccessKey string
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAccessKey, _ = naverapi.VerifiedAccessKey(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSignedTestRequest(t, "test-access-key", "test-secret-key"))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d but got %d", http.StatusOK, rec.Code)
+	}
+	if gotAccessKey != "test-access-key" {
+		t.Errorf("expected the verified access key to be %q but got %q", "test-access-key", gotAccessKey)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSignedTestRequest(t, "test-access-key", "wrong-secret-key"))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d but got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestVerifier_Verify_ShouldFailWithoutSecretLookup(t *testing.T) {
+	v := naverapi.Verifier{Clock: fixedTimeClock{fixedTime: testSigningTime}}
+
+	err := v.Verify(newSignedTestRequest(t, "test-access-key", "test-secret-key"))
+	var verr *naverapi.VerificationError
+	if !errors.As(err, &verr) || verr.Reason != naverapi.ReasonLookupFailed {
+		t.Fatalf("expected a %s verification error but got: %v", naverapi.ReasonLookupFailed, err)
+	}
+	if !errors.Is(err, naverapi.ErrMissingSecretLookup) {
+		t.Errorf("expected error %v but got: %v", naverapi.ErrMissingSecretLookup, err)
+	}
+
+	rec := httptest.NewRecorder()
+	v.Middleware(http.NotFoundHandler()).ServeHTTP(rec, newSignedTestRequest(t, "test-access-key", "test-secret-key"))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d but got %d", http.StatusInternalServerError, rec.Code)
+	}
+}